@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/notify"
+	"github.com/spf13/cobra"
+)
+
+var validateOnline bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Config file helpers",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for mistakes",
+	Long: `Parses the config file and its includes strictly and reports every problem
+with its file and line: unknown fields, missing owner/repo, malformed names,
+duplicates, invalid ages, regexps, webhook URLs and templates.
+
+With --online it also checks that every repo exists and is visible to your
+token, and that webhook hosts resolve. Exits non-zero if any error is found.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, issues, err := config.Check(cfgPath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Using %s\n", cfgPath)
+		if personal := config.PersonalPath(); !cfg.Loads(cfgPath, personal) {
+			if _, err := os.Stat(personal); err == nil {
+				fmt.Printf("  %s is not loaded (add it under include: to merge it)\n", personal)
+			}
+		}
+		if len(issues.Errors()) == 0 {
+			if _, err := notify.New(cfg.Notify); err != nil {
+				issues = append(issues, config.Issue{File: cfgPath, Msg: err.Error()})
+			}
+		}
+		if validateOnline && len(issues.Errors()) == 0 {
+			issues = append(issues, checkOnline(context.Background(), cfg)...)
+		}
+
+		for _, i := range issues {
+			fmt.Fprintln(os.Stderr, i)
+		}
+		if n := len(issues.Errors()); n > 0 {
+			return fmt.Errorf("%d error(s) in %s", n, cfgPath)
+		}
+		fmt.Printf("✅ %s is valid (%d repo(s), %d source(s))\n", cfgPath, len(cfg.Repos), len(cfg.Sources))
+		return nil
+	},
+}
+
+// checkOnline verifies repos against the GitHub API and resolves webhook hosts.
+func checkOnline(ctx context.Context, cfg *config.Config) config.Issues {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	var issues config.Issues
+	gh := github.New()
+	for _, r := range cfg.Repos {
+		_, err := gh.GetRepo(ctx, r.Owner, r.Repo)
+		switch {
+		case errors.Is(err, github.ErrNotFound):
+			issues = append(issues, config.Issue{File: cfgPath, Msg: fmt.Sprintf("%s/%s: repo not found or not visible to your token", r.Owner, r.Repo)})
+		case err != nil:
+			issues = append(issues, config.Issue{File: cfgPath, Msg: fmt.Sprintf("%s/%s: %v", r.Owner, r.Repo, err)})
+		}
+	}
+	for _, wh := range cfg.Notify.Webhooks {
+		u, err := url.Parse(wh.URL)
+		if err != nil {
+			continue
+		}
+		if _, err := net.DefaultResolver.LookupHost(ctx, u.Hostname()); err != nil {
+			issues = append(issues, config.Issue{File: cfgPath, Msg: fmt.Sprintf("webhook host %s does not resolve: %v", u.Hostname(), err)})
+		}
+	}
+	return issues
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema for the config file",
+	Long: `Prints a JSON Schema describing repos.yaml / .reprac.yaml. Editors using
+yaml-language-server pick it up from a modeline at the top of the file:
+
+  # yaml-language-server: $schema=https://raw.githubusercontent.com/adhaniscuber/reprac/main/internal/config/reprac.schema.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Stdout.Write(config.Schema)
+	},
+}
+
+func init() {
+	configValidateCmd.Flags().BoolVar(&validateOnline, "online", false, "also check repos exist on GitHub and webhook hosts resolve")
+	configCmd.AddCommand(configValidateCmd, configSchemaCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/checker"
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/history"
+	"github.com/adhaniscuber/reprac/internal/notify"
+	"github.com/adhaniscuber/reprac/internal/sources"
+	"github.com/adhaniscuber/reprac/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonInterval time.Duration
+	daemonOnce     bool
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Check repos periodically in the background, without the TUI",
+	Long: `Runs every repo check on a schedule, writes the results to the state file
+and fires notifications on status changes. The TUI reads the same state file
+on startup so it opens with the last-known results.
+
+The config file is re-read (and its sources re-expanded) before every run, so
+edits and new repos take effect without a restart.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if daemonInterval < time.Minute {
+			return fmt.Errorf("--interval must be at least 1m")
+		}
+		// Fail fast on a broken config; later reload errors are only logged.
+		if _, err := config.Load(cfgPath); err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		logger := log.New(os.Stdout, "", log.LstdFlags)
+		gh := github.New()
+		d := &daemon{gh: gh, checker: checker.New(gh), history: history.Open(historyPath), log: logger}
+
+		if daemonOnce {
+			d.run(ctx)
+			return nil
+		}
+		logger.Printf("reprac daemon: checking every %s, state at %s", daemonInterval, statePath)
+		checker.Every(ctx, daemonInterval, func(ctx context.Context) { d.run(ctx) })
+		logger.Printf("reprac daemon: stopped")
+		return nil
+	},
+}
+
+type daemon struct {
+	gh      *github.Client
+	checker *checker.Checker
+	history *history.Store
+	log     *log.Logger
+}
+
+// run performs one check cycle: reload config, check, notify, persist.
+// It returns the config and results it worked from (nil on a config error).
+func (d *daemon) run(ctx context.Context) (*config.Config, []github.RepoStatus) {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		d.log.Printf("loading config: %v", err)
+		return nil, nil
+	}
+	if err := sources.Expand(ctx, d.gh, cfg); err != nil {
+		d.log.Printf("%v", err)
+	}
+	if err := d.checker.SetRisks(cfg.RiskRules()); err != nil {
+		d.log.Printf("risk rules: %v", err)
+	}
+	notifier, err := notify.New(cfg.Notify)
+	if err != nil {
+		d.log.Printf("notify config: %v", err)
+	}
+	st, err := state.Load(statePath)
+	if err != nil {
+		d.log.Printf("%v (starting fresh)", err)
+		st = &state.State{Repos: make(map[string]github.RepoStatus)}
+	}
+
+	start := time.Now()
+	results := d.checker.CheckAll(ctx, cfg.Repos)
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+
+	var events []notify.Event
+	behind := 0
+	for _, res := range results {
+		var prev *github.RepoStatus
+		if p, ok := st.Repos[res.FullName()]; ok {
+			prev = &p
+		}
+		events = append(events, notify.Diff(prev, res, notifier.Threshold())...)
+		st.Put(res)
+		if res.NeedsDeploy() {
+			behind++
+		}
+		d.log.Print(describe(res))
+	}
+
+	if err := state.Save(statePath, st); err != nil {
+		d.log.Printf("saving state: %v", err)
+	}
+	if err := d.history.Add(results...); err != nil {
+		d.log.Printf("recording history: %v", err)
+	}
+	if err := notifier.Send(ctx, events); err != nil {
+		d.log.Printf("notify: %v", err)
+	}
+	d.log.Printf("checked %d repos in %s: %d need deploy, %d notification(s)",
+		len(results), time.Since(start).Round(time.Millisecond), behind, len(events))
+	return cfg, results
+}
+
+// describe renders a one-line, log-friendly summary of a result.
+func describe(r github.RepoStatus) string {
+	switch r.Status {
+	case github.StatusBehind:
+		return fmt.Sprintf("  ▲ %-40s +%d since %s%s", r.FullName(), r.CommitsAhead, r.TagName, describeRisks(r))
+	case github.StatusBroken:
+		return fmt.Sprintf("  ▲ %-40s +%d since %s, CI failing%s", r.FullName(), r.CommitsAhead, r.TagName, describeRisks(r))
+	case github.StatusDiverged:
+		ci := ""
+		if r.CI == github.CIFailing {
+			ci = ", CI failing"
+		}
+		return fmt.Sprintf("  ⑂ %-40s +%d, %s%s%s", r.FullName(), r.CommitsAhead, r.Divergence(), ci, describeRisks(r))
+	case github.StatusClean:
+		return fmt.Sprintf("  ✓ %-40s %s", r.FullName(), r.TagName)
+	case github.StatusNoRelease:
+		return fmt.Sprintf("  ◈ %-40s no release", r.FullName())
+	case github.StatusError:
+		return fmt.Sprintf("  ✗ %-40s %s", r.FullName(), r.ErrorMsg)
+	}
+	return fmt.Sprintf("  ? %s", r.FullName())
+}
+
+// describeRisks renders matched risk rules as " ⚠ migration ⚠ breaking".
+func describeRisks(r github.RepoStatus) string {
+	var sb strings.Builder
+	for _, name := range r.Risks {
+		sb.WriteString(" ⚠ " + name)
+	}
+	return sb.String()
+}
+
+func init() {
+	daemonCmd.Flags().DurationVarP(&daemonInterval, "interval", "i", 15*time.Minute, "time between check runs")
+	daemonCmd.Flags().BoolVar(&daemonOnce, "once", false, "run a single check cycle and exit")
+	rootCmd.AddCommand(daemonCmd)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var historyDays int
+
+var historyCmd = &cobra.Command{
+	Use:   "history owner/repo",
+	Short: "Show recorded check results for a repo",
+	Long: `Prints every recorded check for a repo over the last --days days, with a
+sparkline of unreleased commits per day. Results are recorded by the TUI and
+by reprac daemon.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo := args[0]
+		if strings.Count(repo, "/") != 1 {
+			return fmt.Errorf("expected owner/repo, got %q", repo)
+		}
+
+		now := time.Now()
+		records, err := history.Open(historyPath).Query(repo, now.AddDate(0, 0, -historyDays))
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			fmt.Printf("No history for %s in the last %d days.\n", repo, historyDays)
+			return nil
+		}
+
+		fmt.Printf("%s  %s  (last %d days)\n\n", repo, history.Sparkline(history.Daily(records, historyDays, now)), historyDays)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CHECKED\tSTATUS\tTAG\tUNRELEASED")
+		for _, r := range records {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", r.Time.Local().Format("2006-01-02 15:04"), r.Status, r.Tag, r.CommitsAhead)
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyDays, "days", "d", 30, "how many days back to show")
+	rootCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFilter github.RepoFilter
+	importDryRun bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Add every repo of a GitHub org, team or topic to the config",
+	Long: `Lists repositories through the GitHub API and adds the ones not tracked yet
+to the config file. Already tracked repos are skipped.
+
+Examples:
+  reprac import --org acme --exclude-archived
+  reprac import --org acme --team payments
+  reprac import --org acme --topic service --match '^svc-'
+  reprac import --org acme --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if importFilter.Org == "" {
+			return fmt.Errorf("--org is required")
+		}
+		cfg, err := config.Load(cfgPath)
+		if err != nil {
+			return err
+		}
+
+		found, err := github.New().ListRepos(context.Background(), importFilter)
+		if err != nil {
+			return fmt.Errorf("listing repos: %w", err)
+		}
+
+		added := cfg.Merge(reposToConfig(found))
+		for _, r := range added {
+			fmt.Printf("  + %s/%s\n", r.Owner, r.Repo)
+		}
+		skipped := len(found) - len(added)
+
+		if importDryRun {
+			fmt.Printf("Would add %d repo(s), %d already tracked (dry run).\n", len(added), skipped)
+			return nil
+		}
+		if len(added) > 0 {
+			if err := config.Save(cfgPath, cfg); err != nil {
+				return fmt.Errorf("saving config: %w", err)
+			}
+		}
+		fmt.Printf("✅ Added %d repo(s), %d already tracked.\n", len(added), skipped)
+		return nil
+	},
+}
+
+// reposToConfig converts listed repositories into config entries.
+func reposToConfig(repos []github.Repository) []config.RepoConfig {
+	out := make([]config.RepoConfig, len(repos))
+	for i, r := range repos {
+		out[i] = config.RepoConfig{Owner: r.Owner, Repo: r.Name}
+	}
+	return out
+}
+
+func init() {
+	f := importCmd.Flags()
+	f.StringVar(&importFilter.Org, "org", "", "GitHub organization (or user) to import from")
+	f.StringVar(&importFilter.Team, "team", "", "only repos the given team (slug) has access to")
+	f.StringVar(&importFilter.Topic, "topic", "", "only repos tagged with this topic")
+	f.BoolVar(&importFilter.ExcludeArchived, "exclude-archived", false, "skip archived repos")
+	f.StringVar(&importFilter.Match, "match", "", "only repos whose name matches this regexp")
+	f.BoolVar(&importDryRun, "dry-run", false, "print what would be added without saving")
+	rootCmd.AddCommand(importCmd)
+}
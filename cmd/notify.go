@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/notify"
+	"github.com/spf13/cobra"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Notification helpers",
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a sample event to every configured notification sink",
+	Long: `Sends a synthetic "needs deploy" event through the desktop and webhook
+sinks configured under notify: in repos.yaml. Point a webhook url at a local
+listener (e.g. http://localhost:9000) to inspect the payload.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(cfgPath)
+		if err != nil {
+			return err
+		}
+		n, err := notify.New(cfg.Notify)
+		if err != nil {
+			return err
+		}
+		if !n.Enabled() {
+			return fmt.Errorf("no notification sinks configured (add notify: to %s)", cfgPath)
+		}
+
+		prev := github.RepoStatus{Owner: "your-org", Repo: "your-app", Branch: "main", TagName: "v1.0.0", Status: github.StatusClean}
+		cur := prev
+		cur.Status = github.StatusBehind
+		cur.CommitsAhead = 3
+		cur.LastChecked = time.Now()
+
+		events := notify.Diff(&prev, cur, 0)
+		if err := n.Send(context.Background(), events); err != nil {
+			return err
+		}
+		fmt.Println("✅ Sent test notification")
+		return nil
+	},
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyTestCmd)
+	rootCmd.AddCommand(notifyCmd)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/adhaniscuber/reprac/internal/checker"
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFormat   string
+	reportOutput   string
+	reportReleases int
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Release cadence report (frequency, lead time, drift)",
+	Long: `Computes per-repo release frequency, mean time between releases, mean lead
+time from commit to release, and current drift over the last --releases
+releases, and prints it as Markdown, HTML or JSON.
+
+Examples:
+  reprac report                         # Markdown to stdout
+  reprac report -f html -o cadence.html
+  reprac report -f json --releases 20`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if reportReleases < 2 {
+			return fmt.Errorf("--releases must be at least 2")
+		}
+		ctx := context.Background()
+		gh := github.New()
+		cfg, err := loadConfig(ctx, gh)
+		if err != nil {
+			return err
+		}
+
+		chk := checker.New(gh)
+		if err := chk.SetRisks(cfg.RiskRules()); err != nil {
+			return err
+		}
+		statuses := chk.CheckAll(ctx, cfg.Repos)
+
+		rows := make([]report.Analytics, len(statuses))
+		checker.ForEach(len(statuses), func(i int) {
+			rows[i] = report.Analyze(ctx, gh, statuses[i], reportReleases)
+		})
+
+		out := os.Stdout
+		if reportOutput != "" {
+			f, err := os.Create(reportOutput)
+			if err != nil {
+				return fmt.Errorf("creating report: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		return report.Write(out, reportFormat, rows)
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringVarP(&reportFormat, "format", "f", "md", "output format: md, html or json")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "write to file instead of stdout")
+	reportCmd.Flags().IntVarP(&reportReleases, "releases", "n", 10, "how many recent releases to analyse per repo")
+	rootCmd.AddCommand(reportCmd)
+}
@@ -1,17 +1,26 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/adhaniscuber/reprac/internal/config"
 	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/history"
+	"github.com/adhaniscuber/reprac/internal/notify"
+	"github.com/adhaniscuber/reprac/internal/sources"
+	"github.com/adhaniscuber/reprac/internal/state"
 	"github.com/adhaniscuber/reprac/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 )
 
-var cfgPath string
+var (
+	cfgPath     string
+	statePath   string
+	historyPath string
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "reprac",
@@ -25,6 +34,7 @@ Examples:
   reprac                          # run with default config
   reprac --config ~/repos.yaml   # run with custom config
   reprac init                     # create a sample config file
+  reprac daemon --interval 10m    # check in the background
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load(cfgPath)
@@ -32,10 +42,20 @@ Examples:
 			return err
 		}
 
+		notifier, err := notify.New(cfg.Notify)
+		if err != nil {
+			return err
+		}
+
 		gh := github.New()
 
-		m := ui.New(cfgPath, cfg, gh)
-		p := tea.NewProgram(m, tea.WithAltScreen())
+		m := ui.New(cfg, gh, ui.Options{
+			ConfigPath:  cfgPath,
+			StatePath:   statePath,
+			HistoryPath: historyPath,
+			Notifier:    notifier,
+		})
+		p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 		_, err = p.Run()
 		return err
 	},
@@ -72,6 +92,19 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// loadConfig reads the config and expands its sources. A failing source is
+// reported on stderr; the repos that did resolve are still used.
+func loadConfig(ctx context.Context, gh *github.Client) (*config.Config, error) {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := sources.Expand(ctx, gh, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v\n", err)
+	}
+	return cfg, nil
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -81,6 +114,8 @@ func Execute() {
 func init() {
 	defaultCfg := config.DefaultPath()
 	rootCmd.PersistentFlags().StringVarP(&cfgPath, "config", "c", defaultCfg, "path to repos.yaml config file")
+	rootCmd.PersistentFlags().StringVar(&statePath, "state", state.DefaultPath(), "path to the last-known results file shared with the daemon")
+	rootCmd.PersistentFlags().StringVar(&historyPath, "history", history.DefaultPath(), "path to the check history database")
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(versionCmd)
 }
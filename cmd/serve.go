@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/checker"
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/history"
+	"github.com/adhaniscuber/reprac/internal/server"
+	"github.com/adhaniscuber/reprac/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr     string
+	serveInterval time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a read-only web dashboard and JSON API",
+	Long: `Runs the same checks as reprac daemon in the background and serves the
+results over HTTP, so a team can share one dashboard (and one API rate limit).
+
+Routes:
+  /                          web dashboard (auto-refreshes every minute)
+  /api/repos                 JSON array of repo statuses
+  /api/repos/{owner}/{repo}  JSON status for one repo
+  /metrics                   Prometheus metrics`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if serveInterval < time.Minute {
+			return fmt.Errorf("--interval must be at least 1m")
+		}
+		cfg, err := config.Load(cfgPath)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		logger := log.New(os.Stdout, "", log.LstdFlags)
+		gh := github.New()
+		srv := server.New(gh)
+		if st, err := state.Load(statePath); err == nil {
+			srv.Seed(cfg, st.Repos)
+		}
+
+		d := &daemon{gh: gh, checker: checker.New(gh), history: history.Open(historyPath), log: logger}
+		go checker.Every(ctx, serveInterval, func(ctx context.Context) {
+			if cfg, results := d.run(ctx); cfg != nil {
+				srv.Update(cfg, results)
+			}
+		})
+
+		httpSrv := &http.Server{
+			Addr:              serveAddr,
+			Handler:           srv.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = httpSrv.Shutdown(shutdownCtx)
+		}()
+
+		logger.Printf("reprac serve: listening on %s, checking every %s", serveAddr, serveInterval)
+		if err := httpSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "address to listen on")
+	serveCmd.Flags().DurationVarP(&serveInterval, "interval", "i", 5*time.Minute, "time between check runs")
+	rootCmd.AddCommand(serveCmd)
+}
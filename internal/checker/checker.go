@@ -0,0 +1,91 @@
+package checker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/risk"
+)
+
+// concurrency caps parallel repo checks so a large config doesn't burst the API.
+const concurrency = 4
+
+// Checker runs repo checks outside the TUI (daemon, server).
+type Checker struct {
+	gh *github.Client
+
+	mu    sync.RWMutex
+	risks []risk.Rule
+}
+
+func New(gh *github.Client) *Checker {
+	return &Checker{gh: gh}
+}
+
+// SetRisks sets the rules results are flagged with from now on.
+func (c *Checker) SetRisks(rules []config.RiskRule) error {
+	compiled, err := risk.Compile(rules)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.risks = compiled
+	c.mu.Unlock()
+	return nil
+}
+
+// Check fetches the status of a single configured repo.
+func (c *Checker) Check(ctx context.Context, r config.RepoConfig) github.RepoStatus {
+	start := time.Now()
+	res := c.gh.CheckRepo(ctx, r.Owner, r.Repo)
+	c.mu.RLock()
+	res.Risks = risk.Evaluate(c.risks, res)
+	c.mu.RUnlock()
+	res.Duration = time.Since(start)
+	return res
+}
+
+// CheckAll checks every repo, returning results in config order.
+func (c *Checker) CheckAll(ctx context.Context, repos []config.RepoConfig) []github.RepoStatus {
+	results := make([]github.RepoStatus, len(repos))
+	ForEach(len(repos), func(i int) {
+		results[i] = c.Check(ctx, repos[i])
+	})
+	return results
+}
+
+// ForEach calls fn for every index below n, running at most concurrency calls
+// at once, and returns when all are done. Use it for any per-repo fan-out of
+// API requests.
+func ForEach(n int, fn func(i int)) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Every calls fn immediately and then on each interval tick until ctx is done.
+func Every(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	fn(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
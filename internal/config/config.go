@@ -4,63 +4,343 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // RepoConfig represents a single tracked repository.
 type RepoConfig struct {
-	Owner string `yaml:"owner"`
-	Repo  string `yaml:"repo"`
-	Notes string `yaml:"notes,omitempty"`
+	Owner            string        `yaml:"owner"`
+	Repo             string        `yaml:"repo"`
+	Notes            string        `yaml:"notes,omitempty"`
+	MaxUnreleasedAge AgeThresholds `yaml:"max_unreleased_age,omitempty"`
+	Pinned           bool          `yaml:"pinned,omitempty"` // listed above unpinned repos
+
+	// Origin describes where an entry came from when it wasn't declared under
+	// repos: (e.g. a source). Such entries are never written back by Save.
+	Origin string `yaml:"-"`
+}
+
+// AgeThresholds escalates a repo once its oldest unreleased commit exceeds them.
+// A zero threshold is disabled.
+type AgeThresholds struct {
+	Warning  Age `yaml:"warning,omitempty"`
+	Critical Age `yaml:"critical,omitempty"`
+}
+
+// AgeLevel is how stale unreleased work is relative to AgeThresholds.
+type AgeLevel int
+
+const (
+	AgeOK AgeLevel = iota
+	AgeWarning
+	AgeCritical
+)
+
+// Level classifies an unreleased age against the thresholds.
+func (t AgeThresholds) Level(age time.Duration) AgeLevel {
+	if age <= 0 {
+		return AgeOK
+	}
+	if t.Critical > 0 && age >= time.Duration(t.Critical) {
+		return AgeCritical
+	}
+	if t.Warning > 0 && age >= time.Duration(t.Warning) {
+		return AgeWarning
+	}
+	return AgeOK
+}
+
+// Age is a duration written in days-friendly form: "14d", "2w", "36h" or a bare number of days.
+type Age time.Duration
+
+// ParseAge parses the Age syntax accepted in the config file.
+func ParseAge(s string) (Age, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return Age(time.Duration(n) * 24 * time.Hour), nil
+	}
+	unit := s[len(s)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		days := n
+		if unit == 'w' {
+			days = n * 7
+		}
+		return Age(time.Duration(days) * 24 * time.Hour), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 7d, 2w, 36h)", s)
+	}
+	return Age(d), nil
+}
+
+func (a Age) String() string {
+	d := time.Duration(a)
+	if d == 0 {
+		return ""
+	}
+	const day = 24 * time.Hour
+	if d%(7*day) == 0 {
+		return fmt.Sprintf("%dw", d/(7*day))
+	}
+	if d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
+
+// UnmarshalYAML parses an age. A bad value is returned as a TypeError, so the
+// decoder records it with its line and carries on with the rest of the file.
+func (a *Age) UnmarshalYAML(node *yaml.Node) error {
+	v, err := ParseAge(node.Value)
+	if err != nil {
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: %v", node.Line, err)}}
+	}
+	*a = v
+	return nil
+}
+
+func (a Age) MarshalYAML() (any, error) {
+	return a.String(), nil
 }
 
 // Config is the root config file structure.
 type Config struct {
-	Repos []RepoConfig `yaml:"repos"`
+	Include []string     `yaml:"include,omitempty"` // other config files or globs, relative to this file
+	Repos   []RepoConfig `yaml:"repos"`
+	Sources []Source     `yaml:"sources,omitempty"`
+	Notify  NotifyConfig `yaml:"notify,omitempty"`
+	Risks   []RiskRule   `yaml:"risks,omitempty"` // nil means DefaultRisks; an empty list disables risk flags
+	Keys    KeyBindings  `yaml:"keys,omitempty"`
 }
 
-// DefaultPath returns the default config file path (~/.config/reprac/repos.yaml).
-func DefaultPath() string {
+// KeyBindings remaps TUI actions to keys, e.g. {"refresh": ["f5", "R"]}.
+// Actions left out keep their default keys.
+type KeyBindings map[string][]string
+
+// KeyAction is a TUI action that can be remapped under keys:.
+type KeyAction struct {
+	Name string   // config name, e.g. "refresh_all"
+	Keys []string // default keys
+	Help string   // short description for the footer and ? overlay
+}
+
+// KeyActions lists every remappable action with its defaults. The TUI builds
+// its key map from it, and the schema's keys enum must list the same names.
+var KeyActions = []KeyAction{
+	{"up", []string{"up", "k"}, "up"},
+	{"down", []string{"down", "j"}, "down"},
+	{"top", []string{"g"}, "top"},
+	{"bottom", []string{"G"}, "bottom"},
+	{"expand", []string{"enter", " "}, "expand"},
+	{"expand_all", []string{"E"}, "expand all"},
+	{"collapse_all", []string{"C"}, "collapse all"},
+	{"refresh_all", []string{"r"}, "refresh all"},
+	{"refresh", []string{"R"}, "refresh repo"},
+	{"add", []string{"a"}, "add"},
+	{"edit", []string{"e"}, "edit"},
+	{"delete", []string{"d"}, "delete"},
+	{"move_up", []string{"K", "shift+up"}, "move up"},
+	{"move_down", []string{"J", "shift+down"}, "move down"},
+	{"pin", []string{"p"}, "pin"},
+	{"undo", []string{"u"}, "undo"},
+	{"redo", []string{"ctrl+r"}, "redo"},
+	{"import", []string{"I"}, "import"},
+	{"analytics", []string{"A"}, "analytics"},
+	{"detail", []string{"v"}, "details"},
+	{"open", []string{"o"}, "open"},
+	{"open_compare", []string{"O"}, "compare"},
+	{"open_release", []string{"t"}, "release"},
+	{"copy", []string{"y"}, "copy link"},
+	{"help", []string{"?"}, "help"},
+	{"quit", []string{"q"}, "quit"},
+}
+
+// KeyActionNames returns the names of KeyActions, in order.
+func KeyActionNames() []string {
+	names := make([]string, len(KeyActions))
+	for i, a := range KeyActions {
+		names[i] = a.Name
+	}
+	return names
+}
+
+// RiskRule flags unreleased changes that touch matching files or whose commit
+// messages match. Paths are globs: "*" stays within a directory, "**" crosses
+// directories, and a pattern without "/" matches the file name at any depth.
+type RiskRule struct {
+	Name    string   `yaml:"name"`              // badge text, e.g. "migration"
+	Paths   []string `yaml:"paths,omitempty"`   // globs on changed file paths
+	Message string   `yaml:"message,omitempty"` // regexp on commit messages
+}
+
+// DefaultRisks are the rules used when the config has no risks: section.
+func DefaultRisks() []RiskRule {
+	return []RiskRule{
+		{Name: "migration", Paths: []string{"**/migrations/**", "**/migrate/**", "*.sql"}},
+		{Name: "dependency", Paths: []string{
+			"go.mod", "go.sum", "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml",
+			"requirements*.txt", "poetry.lock", "Pipfile.lock", "Gemfile.lock", "Cargo.lock", "composer.lock",
+		}},
+		{Name: "infra", Paths: []string{"*.tf", "*.tfvars", "Dockerfile", "docker-compose*.yml", "**/helm/**", "**/k8s/**", ".github/workflows/**"}},
+		{Name: "breaking", Message: `(?m)^BREAKING[ -]CHANGE|^\w+(\([^)]*\))?!:`},
+	}
+}
+
+// RiskRules returns the configured rules, or DefaultRisks if none are set.
+func (c *Config) RiskRules() []RiskRule {
+	if c.Risks == nil {
+		return DefaultRisks()
+	}
+	return c.Risks
+}
+
+// Source is a rule that expands into repos when the config is loaded:
+// every repo of an org (optionally a team's), or a GitHub search query,
+// narrowed by topic, archived state and a name regexp.
+type Source struct {
+	Org             string `yaml:"org,omitempty"`
+	Team            string `yaml:"team,omitempty"`
+	Query           string `yaml:"query,omitempty"` // GitHub repository search, e.g. "org:acme topic:deployable"
+	Topic           string `yaml:"topic,omitempty"`
+	ExcludeArchived bool   `yaml:"exclude_archived,omitempty"`
+	Match           string `yaml:"match,omitempty"` // regexp on the repo name
+
+	Origin string `yaml:"-"` // set for sources pulled in by include:
+}
+
+// String describes the source for display, e.g. "org=acme topic=deployable".
+func (s Source) String() string {
+	var parts []string
+	add := func(k, v string) {
+		if v != "" {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	add("org", s.Org)
+	add("team", s.Team)
+	add("query", s.Query)
+	add("topic", s.Topic)
+	add("match", s.Match)
+	return strings.Join(parts, " ")
+}
+
+// NotifyConfig controls notifications fired when a repo's status changes.
+type NotifyConfig struct {
+	Desktop         bool            `yaml:"desktop,omitempty"`          // notify-send / osascript
+	BehindThreshold int             `yaml:"behind_threshold,omitempty"` // also notify when unreleased commits reach this many
+	Webhooks        []WebhookConfig `yaml:"webhooks,omitempty"`
+}
+
+// WebhookConfig is an outgoing HTTP POST target.
+type WebhookConfig struct {
+	URL      string            `yaml:"url"`
+	Template string            `yaml:"template,omitempty"` // "slack" (default) or "teams"
+	Payload  string            `yaml:"payload,omitempty"`  // custom Go text/template JSON body, overrides template
+	Headers  map[string]string `yaml:"headers,omitempty"`
+}
+
+// Has reports whether owner/repo is already tracked.
+func (c *Config) Has(owner, repo string) bool {
+	for _, r := range c.Repos {
+		if r.Owner == owner && r.Repo == repo {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge appends repos that aren't tracked yet and returns the ones it added.
+func (c *Config) Merge(repos []RepoConfig) []RepoConfig {
+	var added []RepoConfig
+	for _, r := range repos {
+		if c.Has(r.Owner, r.Repo) {
+			continue
+		}
+		c.Repos = append(c.Repos, r)
+		added = append(added, r)
+	}
+	return added
+}
+
+// SortPinned moves pinned repos to the top, keeping the order within each group.
+func (c *Config) SortPinned() {
+	sort.SliceStable(c.Repos, func(i, j int) bool {
+		return c.Repos[i].Pinned && !c.Repos[j].Pinned
+	})
+}
+
+// Dir returns reprac's per-user directory (~/.config/reprac).
+func Dir() string {
 	home := os.Getenv("HOME")
 	if home == "" {
 		if h, err := os.UserHomeDir(); err == nil {
 			home = h
 		}
 	}
-	return filepath.Join(home, ".config", "reprac", "repos.yaml")
+	return filepath.Join(home, ".config", "reprac")
 }
 
-// Load reads and parses a config YAML file.
-func Load(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("config file not found: %s\n\nCreate it with:\n\nrepos:\n  - owner: your-org\n    repo: your-repo\n    notes: \"Optional description\"", path)
-		}
-		return nil, fmt.Errorf("reading config: %w", err)
-	}
+// LocalName is the project-local config file discovered by DefaultPath.
+const LocalName = ".reprac.yaml"
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("parsing config: %w", err)
+// DefaultPath returns the nearest .reprac.yaml in the working directory or one
+// of its parents, falling back to PersonalPath. A local file is used instead
+// of the personal one, not merged with it (unless it includes it).
+func DefaultPath() string {
+	if p := findLocal(); p != "" {
+		return p
 	}
+	return PersonalPath()
+}
 
-	return &cfg, nil
+// PersonalPath is the per-user config, ~/.config/reprac/repos.yaml.
+func PersonalPath() string {
+	return filepath.Join(Dir(), "repos.yaml")
 }
 
-// Save writes the config back to disk.
-func Save(path string, cfg *Config) error {
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return fmt.Errorf("creating config dir: %w", err)
+// Load reads and parses a config YAML file, expanding ${ENV} references and
+// merging any include: files. Pinned repos are listed first. Unknown fields
+// and invalid values are errors; the returned Issues lists each with its line.
+func Load(path string) (*Config, error) {
+	cfg, issues, err := Check(path)
+	if err != nil {
+		return nil, err
 	}
+	if errs := issues.Errors(); len(errs) > 0 {
+		return nil, errs
+	}
+	cfg.SortPinned()
+	return cfg, nil
+}
 
-	data, err := yaml.Marshal(cfg)
+// Check loads path like Load but returns every issue found, warnings
+// included, instead of failing on the first. err is set only when the file
+// can't be read at all.
+func Check(path string) (*Config, Issues, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("marshalling config: %w", err)
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("config file not found: %s\n\nCreate it with:\n\nrepos:\n  - owner: your-org\n    repo: your-repo\n    notes: \"Optional description\"", path)
+		}
+		return nil, nil, fmt.Errorf("reading config: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0644)
+	cfg, issues := parse(path, data)
+	issues = append(issues, cfg.loadIncludes(path, map[string]bool{absPath(path): true})...)
+	return cfg, issues, nil
 }
 
 // InitExample creates a sample config file.
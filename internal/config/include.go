@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envRef matches ${VAR} and ${VAR:-default}.
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv replaces ${VAR} references with environment values. Unset
+// variables become empty unless a ${VAR:-default} is given. A bare $VAR is
+// left alone so dollar signs in notes survive.
+func expandEnv(data []byte) []byte {
+	return envRef.ReplaceAllFunc(data, func(m []byte) []byte {
+		sub := envRef.FindSubmatch(m)
+		if v, ok := os.LookupEnv(string(sub[1])); ok && v != "" {
+			return []byte(v)
+		}
+		return sub[2]
+	})
+}
+
+// loadIncludes merges the repos and sources of every file matched by
+// c.Include. Paths are relative to the including file; nested includes are
+// followed, and seen guards against cycles. Problems in included files are
+// reported against those files.
+func (c *Config) loadIncludes(from string, seen map[string]bool) Issues {
+	var issues Issues
+	dir := filepath.Dir(from)
+	for _, pattern := range c.Include {
+		pattern = expandHome(pattern)
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(dir, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			issues = append(issues, Issue{File: from, Msg: fmt.Sprintf("include %q: %v", pattern, err)})
+			continue
+		}
+		if len(matches) == 0 && !hasGlob(pattern) {
+			issues = append(issues, Issue{File: from, Msg: fmt.Sprintf("include %q: file not found", pattern)})
+			continue
+		}
+		for _, path := range matches {
+			abs := absPath(path)
+			if seen[abs] {
+				continue
+			}
+			seen[abs] = true
+			issues = append(issues, c.include(path, seen)...)
+		}
+	}
+	return issues
+}
+
+// Loads reports whether the config at from reads file, either because it is
+// that file or because one of c.Include (resolved as loadIncludes does)
+// matches it. Nested includes are not followed.
+func (c *Config) Loads(from, file string) bool {
+	want := absPath(file)
+	if absPath(from) == want {
+		return true
+	}
+	for _, pattern := range c.Include {
+		pattern = expandHome(pattern)
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(from), pattern)
+		}
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			if absPath(m) == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Config) include(path string, seen map[string]bool) Issues {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Issues{{File: path, Msg: fmt.Sprintf("reading include: %v", err)}}
+	}
+	inc, issues := parse(path, data)
+	issues = append(issues, ignoredSections(path, data)...)
+	issues = append(issues, inc.loadIncludes(path, seen)...)
+
+	origin := "include: " + filepath.Base(path)
+	for _, r := range inc.Repos {
+		if c.Has(r.Owner, r.Repo) {
+			issues = append(issues, Issue{File: path, Msg: fmt.Sprintf("repo %s/%s is already tracked; this entry is ignored", r.Owner, r.Repo), Warning: true})
+		}
+	}
+	for i := range inc.Repos {
+		if inc.Repos[i].Origin == "" {
+			inc.Repos[i].Origin = origin
+		}
+	}
+	c.Merge(inc.Repos)
+	for _, s := range inc.Sources {
+		if s.Origin == "" {
+			s.Origin = origin
+		}
+		c.Sources = append(c.Sources, s)
+	}
+	return issues
+}
+
+// ignoredInIncludes are the top-level sections an included file may set but
+// that aren't merged: they belong to the top-level config. Unknown keys are
+// already errors.
+var ignoredInIncludes = []string{"notify", "risks", "keys"}
+
+// ignoredSections warns about each section of an included file that is set
+// but not merged.
+func ignoredSections(path string, data []byte) Issues {
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil // reported by parse
+	}
+	var issues Issues
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		k := root.Content[i]
+		if slices.Contains(ignoredInIncludes, k.Value) {
+			issues = append(issues, Issue{File: path, Line: k.Line, Warning: true,
+				Msg: fmt.Sprintf("%s: only include:, repos: and sources: are used from an included file; this section is ignored", k.Value)})
+		}
+	}
+	return issues
+}
+
+// findLocal walks up from the working directory looking for LocalName.
+func findLocal() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		p := filepath.Join(dir, LocalName)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, p[1:])
+		}
+	}
+	return p
+}
+
+func hasGlob(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+func absPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return p
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Save writes cfg's repos back to disk. Entries with an Origin are skipped.
+//
+// The file is edited as a YAML tree rather than re-marshalled: repo entries
+// are inserted, removed, reordered and updated in place, so comments, key
+// order and everything outside repos: (including ${ENV} references) survive.
+// The write is atomic.
+func Save(path string, cfg *Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating config dir: %w", err)
+	}
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real // keep dotfile symlinks intact
+	}
+
+	var own []RepoConfig
+	for _, r := range cfg.Repos {
+		if r.Origin == "" {
+			own = append(own, r)
+		}
+	}
+
+	var doc yaml.Node
+	if data, err := os.ReadFile(path); err == nil {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("parsing config: %w", err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("parsing config: %s: top level must be a mapping", path)
+	}
+
+	repos := mapValue(root, "repos")
+	if repos == nil || repos.Kind != yaml.SequenceNode {
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if repos != nil {
+			*repos = *seq // e.g. "repos:" with no entries
+		} else {
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "repos"}, seq)
+			repos = seq
+		}
+	}
+	if err := syncRepos(repos, own); err != nil {
+		return fmt.Errorf("updating config: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("marshalling config: %w", err)
+	}
+	enc.Close()
+	return writeAtomic(path, buf.Bytes())
+}
+
+// syncRepos rewrites seq so it lists want in order. Existing entries are
+// matched by owner/repo (after ${ENV} expansion) and kept, with only changed
+// fields replaced; new entries are appended as fresh nodes.
+func syncRepos(seq *yaml.Node, want []RepoConfig) error {
+	existing := make(map[string]*yaml.Node)
+	for _, item := range seq.Content {
+		var r RepoConfig
+		if expandedNode(item).Decode(&r) != nil {
+			continue
+		}
+		existing[repoKey(r)] = item
+	}
+
+	content := make([]*yaml.Node, 0, len(want))
+	for _, r := range want {
+		fresh := &yaml.Node{}
+		if err := fresh.Encode(r); err != nil {
+			return err
+		}
+		item, ok := existing[repoKey(r)]
+		if !ok {
+			content = append(content, fresh)
+			continue
+		}
+		delete(existing, repoKey(r)) // a duplicate entry is written only once
+		if err := updateFields(item, fresh, r); err != nil {
+			return err
+		}
+		content = append(content, item)
+	}
+	seq.Content = content
+	if len(content) > 0 {
+		seq.Style &^= yaml.FlowStyle // "repos: []" grows into a block list
+	}
+	return nil
+}
+
+// updateFields replaces the values in item whose decoded form differs from r,
+// so "14d" isn't rewritten as "2w" and ${VAR} references stay as written.
+func updateFields(item, fresh *yaml.Node, r RepoConfig) error {
+	var old RepoConfig
+	if err := expandedNode(item).Decode(&old); err != nil {
+		return err
+	}
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(r)
+	t := nv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" || reflect.DeepEqual(ov.Field(i).Interface(), nv.Field(i).Interface()) {
+			continue
+		}
+		if v := mapValue(fresh, key); v != nil {
+			setMapValue(item, key, v)
+		} else {
+			deleteMapKey(item, key) // now empty and omitted
+		}
+	}
+	return nil
+}
+
+func setMapValue(m *yaml.Node, key string, v *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			v.LineComment = m.Content[i+1].LineComment
+			m.Content[i+1] = v
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, v)
+}
+
+func deleteMapKey(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// expandedNode returns a copy of n with ${ENV} references in scalars expanded.
+// A plain scalar that changed is re-resolved, so "${PORT}" can decode as an int.
+func expandedNode(n *yaml.Node) *yaml.Node {
+	c := *n
+	if c.Kind == yaml.ScalarNode {
+		if v := string(expandEnv([]byte(c.Value))); v != c.Value {
+			c.Value = v
+			if c.Style == 0 {
+				c.Tag = ""
+			}
+		}
+	}
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, child := range n.Content {
+		c.Content[i] = expandedNode(child)
+	}
+	return &c
+}
+
+func repoKey(r RepoConfig) string {
+	return strings.ToLower(r.Owner + "/" + r.Repo)
+}
+
+// writeAtomic replaces path with data via a synced temp file and rename,
+// keeping the existing file's permissions.
+func writeAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".reprac-*.yaml")
+	if err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	// Flush the data before the rename, so a crash can't leave the new name
+	// pointing at an empty file.
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return SyncDir(filepath.Dir(path))
+}
+
+// SyncDir flushes a directory, making a rename into it durable. Platforms
+// that can't fsync a directory (Windows) are skipped.
+func SyncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil && runtime.GOOS != "windows" {
+		return fmt.Errorf("syncing %s: %w", dir, err)
+	}
+	return nil
+}
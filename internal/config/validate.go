@@ -0,0 +1,382 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the JSON Schema for the config file, for editor completion and
+// validation (see `reprac config schema`).
+//
+//go:embed reprac.schema.json
+var Schema []byte
+
+// Issue is a problem found in a config file. Line is 0 when unknown.
+type Issue struct {
+	File    string
+	Line    int
+	Msg     string
+	Warning bool
+}
+
+func (i Issue) String() string {
+	level := "error"
+	if i.Warning {
+		level = "warning"
+	}
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", i.File, i.Line, level, i.Msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.File, level, i.Msg)
+}
+
+// Issues is a list of problems; as an error it lists one per line.
+type Issues []Issue
+
+func (is Issues) Error() string {
+	lines := make([]string, len(is))
+	for i, issue := range is {
+		lines[i] = issue.String()
+	}
+	return "invalid config:\n  " + strings.Join(lines, "\n  ")
+}
+
+// Errors returns only the non-warning issues.
+func (is Issues) Errors() Issues {
+	var out Issues
+	for _, i := range is {
+		if !i.Warning {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+var (
+	// GitHub logins: alphanumerics and single hyphens, max 39 chars.
+	validOwner = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,37}[A-Za-z0-9])?$`)
+	// Repo names: alphanumerics, '.', '-' and '_', max 100 chars.
+	validRepo = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+
+	typeErrLine  = regexp.MustCompile(`^line (\d+): (.*)$`)
+	unknownField = regexp.MustCompile(`^field (\S+) not found in type (\S+)$`)
+	yamlErrLine  = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+)
+
+// parse strictly decodes one config file and validates it, reporting
+// problems with line numbers. ${ENV} references are expanded in scalar values
+// after parsing, so a value containing ": ", "#" or newlines can't change the
+// document's structure.
+func parse(path string, data []byte) (*Config, Issues) {
+	var cfg Config
+	var issues Issues
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		line, msg := 0, err.Error()
+		if m := yamlErrLine.FindStringSubmatch(msg); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			msg = m[2]
+		}
+		return &cfg, Issues{{File: path, Line: line, Msg: msg}}
+	}
+	if len(doc.Content) == 0 {
+		return &cfg, nil
+	}
+	root := expandedNode(doc.Content[0])
+
+	// Unknown fields come from a strict decode of the file as written. Its
+	// other errors are about unexpanded values and are left to the decode of
+	// the expanded tree below.
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var te *yaml.TypeError
+	if err := dec.Decode(new(Config)); errors.As(err, &te) {
+		for _, e := range te.Errors {
+			if m := typeErrLine.FindStringSubmatch(e); m != nil && unknownField.MatchString(m[2]) {
+				issues = append(issues, typeErrorIssue(path, e))
+			}
+		}
+	}
+
+	if err := root.Decode(&cfg); err != nil {
+		if !errors.As(err, &te) {
+			return &cfg, append(issues, Issue{File: path, Msg: err.Error()})
+		}
+		for _, e := range te.Errors {
+			issues = append(issues, typeErrorIssue(path, e))
+		}
+	}
+
+	issues = append(issues, validateDoc(path, root)...)
+	return &cfg, issues
+}
+
+// typeErrorIssue turns a yaml.v3 decode error line into an Issue, rewording
+// unknown-field errors and suggesting the field that was probably meant.
+func typeErrorIssue(path, e string) Issue {
+	issue := Issue{File: path, Msg: e}
+	if m := typeErrLine.FindStringSubmatch(e); m != nil {
+		issue.Line, _ = strconv.Atoi(m[1])
+		issue.Msg = m[2]
+	}
+	if m := unknownField.FindStringSubmatch(issue.Msg); m != nil {
+		issue.Msg = fmt.Sprintf("unknown field %q", m[1])
+		if s := suggestField(m[1]); s != "" {
+			issue.Msg += fmt.Sprintf(" (did you mean %q?)", s)
+		}
+	}
+	return issue
+}
+
+// validateDoc runs semantic checks that need positions from the YAML tree.
+func validateDoc(path string, root *yaml.Node) Issues {
+	var issues Issues
+	add := func(n *yaml.Node, warning bool, format string, args ...any) {
+		issues = append(issues, Issue{File: path, Line: n.Line, Msg: fmt.Sprintf(format, args...), Warning: warning})
+	}
+
+	if repos := mapValue(root, "repos"); repos != nil && repos.Kind == yaml.SequenceNode {
+		firstSeen := make(map[string]int)
+		for i, item := range repos.Content {
+			var r RepoConfig
+			if item.Decode(&r) != nil {
+				continue // reported by the strict decode
+			}
+			switch {
+			case r.Owner == "":
+				add(item, false, "repos[%d]: owner is required", i)
+			case !validOwner.MatchString(r.Owner):
+				add(item, false, "repos[%d]: invalid owner %q", i, r.Owner)
+			}
+			switch {
+			case r.Repo == "":
+				add(item, false, "repos[%d]: repo is required", i)
+			case !validRepo.MatchString(r.Repo):
+				add(item, false, "repos[%d]: invalid repo name %q", i, r.Repo)
+			}
+			if r.Owner != "" && r.Repo != "" {
+				key := strings.ToLower(r.Owner + "/" + r.Repo)
+				if line, ok := firstSeen[key]; ok {
+					add(item, false, "duplicate repo %s/%s (first declared on line %d)", r.Owner, r.Repo, line)
+				} else {
+					firstSeen[key] = item.Line
+				}
+			}
+			if age := r.MaxUnreleasedAge; age.Warning > 0 && age.Critical > 0 && age.Warning > age.Critical {
+				add(item, false, "repos[%d]: max_unreleased_age warning (%s) is later than critical (%s)", i, age.Warning, age.Critical)
+			}
+		}
+	}
+
+	if sources := mapValue(root, "sources"); sources != nil && sources.Kind == yaml.SequenceNode {
+		for i, item := range sources.Content {
+			var s Source
+			if item.Decode(&s) != nil {
+				continue
+			}
+			switch {
+			case s.Org == "" && s.Query == "":
+				add(item, false, "sources[%d]: org or query is required", i)
+			case s.Team != "" && s.Org == "":
+				add(item, false, "sources[%d]: team requires org", i)
+			case s.Query != "" && (s.Org != "" || s.Team != ""):
+				add(item, true, "sources[%d]: org/team are ignored when query is set", i)
+			}
+			if s.Org != "" && !validOwner.MatchString(s.Org) {
+				add(item, false, "sources[%d]: invalid org %q", i, s.Org)
+			}
+			if s.Match != "" {
+				if _, err := regexp.Compile(s.Match); err != nil {
+					add(item, false, "sources[%d]: invalid match regexp: %v", i, err)
+				}
+			}
+		}
+	}
+
+	if risks := mapValue(root, "risks"); risks != nil && risks.Kind == yaml.SequenceNode {
+		for i, item := range risks.Content {
+			var rr RiskRule
+			if item.Decode(&rr) != nil {
+				continue
+			}
+			if rr.Name == "" {
+				add(item, false, "risks[%d]: name is required", i)
+			}
+			if len(rr.Paths) == 0 && rr.Message == "" {
+				add(item, false, "risks[%d]: paths or message is required", i)
+			}
+			if rr.Message != "" {
+				if _, err := regexp.Compile(rr.Message); err != nil {
+					add(item, false, "risks[%d]: invalid message regexp: %v", i, err)
+				}
+			}
+		}
+	}
+
+	if keys := mapValue(root, "keys"); keys != nil && keys.Kind == yaml.MappingNode {
+		// Effective keys per action (defaults unless remapped), to find clashes.
+		bound := make(map[string][]string, len(KeyActions))
+		for _, a := range KeyActions {
+			bound[a.Name] = a.Keys
+		}
+		remapped := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(keys.Content); i += 2 {
+			name, value := keys.Content[i], keys.Content[i+1]
+			if !slices.Contains(KeyActionNames(), name.Value) {
+				msg := fmt.Sprintf("keys: unknown action %q", name.Value)
+				if s := closest(name.Value, KeyActionNames()); s != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", s)
+				}
+				add(name, false, "%s", msg)
+				continue
+			}
+			var list []string
+			if value.Decode(&list) != nil {
+				continue // reported by the strict decode
+			}
+			if len(list) == 0 || slices.Contains(list, "") {
+				add(value, false, "keys.%s: needs at least one non-empty key", name.Value)
+				continue
+			}
+			bound[name.Value] = list
+			remapped[name.Value] = value
+		}
+		owner := make(map[string]string)
+		for _, a := range KeyActions {
+			for _, k := range bound[a.Name] {
+				prev, taken := owner[k]
+				if !taken {
+					owner[k] = a.Name
+					continue
+				}
+				if prev == a.Name {
+					continue
+				}
+				at := remapped[a.Name]
+				if at == nil {
+					at = remapped[prev]
+				}
+				add(at, false, "keys: %q is bound to both %s and %s", k, prev, a.Name)
+			}
+		}
+	}
+
+	if notify := mapValue(root, "notify"); notify != nil {
+		if t := mapValue(notify, "behind_threshold"); t != nil {
+			if n, err := strconv.Atoi(t.Value); err == nil && n < 0 {
+				add(t, false, "notify.behind_threshold must not be negative")
+			}
+		}
+		if hooks := mapValue(notify, "webhooks"); hooks != nil && hooks.Kind == yaml.SequenceNode {
+			for i, item := range hooks.Content {
+				var wh WebhookConfig
+				if item.Decode(&wh) != nil {
+					continue
+				}
+				if wh.URL == "" {
+					add(item, false, "notify.webhooks[%d]: url is required", i)
+				} else if u, err := url.Parse(wh.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					add(item, false, "notify.webhooks[%d]: url must be an absolute http(s) URL", i)
+				}
+				if wh.Template != "" && wh.Template != "slack" && wh.Template != "teams" {
+					add(item, false, "notify.webhooks[%d]: unknown template %q (want slack or teams)", i, wh.Template)
+				}
+				if wh.Payload != "" {
+					if _, err := template.New("").Funcs(template.FuncMap{"json": func(any) string { return "" }}).Parse(wh.Payload); err != nil {
+						add(item, false, "notify.webhooks[%d]: invalid payload template: %v", i, err)
+					}
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// mapValue returns the value node for key in a mapping node, or nil.
+func mapValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// knownFields lists every yaml field name used by the config types.
+var knownFields = func() []string {
+	var out []string
+	seen := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Slice || t.Kind() == reflect.Map || t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || seen[t] || t == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			out = append(out, name)
+			walk(f.Type)
+		}
+	}
+	walk(reflect.TypeOf(Config{}))
+	return out
+}()
+
+// suggestField returns the known field closest to name, if it's a likely typo.
+func suggestField(name string) string {
+	return closest(name, knownFields)
+}
+
+// closest returns the candidate closest to name, if it's a likely typo.
+func closest(name string, candidates []string) string {
+	best, bestDist := "", 3
+	for _, f := range candidates {
+		if d := editDistance(name, f); d < bestDist {
+			best, bestDist = f, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
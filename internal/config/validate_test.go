@@ -0,0 +1,39 @@
+package config
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// The schema is a static file (editors fetch it by URL), so its keys enum is
+// kept in step with KeyActions by hand.
+func TestSchemaKeyActions(t *testing.T) {
+	var schema struct {
+		Properties struct {
+			Keys struct {
+				PropertyNames struct {
+					Enum []string `json:"enum"`
+				} `json:"propertyNames"`
+			} `json:"keys"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := schema.Properties.Keys.PropertyNames.Enum, KeyActionNames(); !slices.Equal(got, want) {
+		t.Errorf("reprac.schema.json keys enum = %v\nwant KeyActions %v", got, want)
+	}
+}
+
+func TestKeyActionDefaultsDontClash(t *testing.T) {
+	owner := make(map[string]string)
+	for _, a := range KeyActions {
+		for _, k := range a.Keys {
+			if prev, ok := owner[k]; ok {
+				t.Errorf("%q is a default for both %s and %s", k, prev, a.Name)
+			}
+			owner[k] = a.Name
+		}
+	}
+}
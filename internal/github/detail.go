@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// PullRequest is an open PR against a branch, or one merged into it.
+type PullRequest struct {
+	Number int       `json:"number"`
+	Title  string    `json:"title"`
+	Author string    `json:"author"`
+	State  string    `json:"state"` // "open" or "merged"
+	Draft  bool      `json:"draft"`
+	URL    string    `json:"url"`
+	Date   time.Time `json:"date"` // merged_at for merged PRs, created_at for open ones
+}
+
+// PullRequests returns PRs merged into branch since the given time followed by
+// PRs still open against it, newest first within each group.
+func (c *Client) PullRequests(ctx context.Context, owner, repo, branch string, since time.Time) ([]PullRequest, error) {
+	type apiPR struct {
+		Number    int       `json:"number"`
+		Title     string    `json:"title"`
+		Draft     bool      `json:"draft"`
+		HTMLURL   string    `json:"html_url"`
+		CreatedAt time.Time `json:"created_at"`
+		User      struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		PullRequest struct {
+			MergedAt time.Time `json:"merged_at"`
+		} `json:"pull_request"` // search results only
+	}
+
+	var merged []PullRequest
+	if !since.IsZero() {
+		q := fmt.Sprintf("repo:%s/%s is:pr is:merged base:%s merged:>=%s", owner, repo, branch, since.UTC().Format(time.RFC3339))
+		var res struct {
+			Items []apiPR `json:"items"`
+		}
+		path := "/search/issues?sort=updated&per_page=" + fmt.Sprint(perPage) + "&q=" + url.QueryEscape(q)
+		if err := c.get(ctx, path, &res); err != nil {
+			return nil, err
+		}
+		for _, p := range res.Items {
+			merged = append(merged, PullRequest{
+				Number: p.Number, Title: p.Title, Author: p.User.Login, State: "merged",
+				URL: p.HTMLURL, Date: p.PullRequest.MergedAt,
+			})
+		}
+		sort.Slice(merged, func(i, j int) bool { return merged[i].Date.After(merged[j].Date) })
+	}
+
+	var open []apiPR
+	path := fmt.Sprintf("/repos/%s/%s/pulls?state=open&base=%s&per_page=%d", owner, repo, url.QueryEscape(branch), perPage)
+	if err := c.get(ctx, path, &open); err != nil {
+		return nil, err
+	}
+	out := merged
+	for _, p := range open {
+		out = append(out, PullRequest{
+			Number: p.Number, Title: p.Title, Author: p.User.Login, State: "open",
+			Draft: p.Draft, URL: p.HTMLURL, Date: p.CreatedAt,
+		})
+	}
+	return out, nil
+}
+
+// Check is one CI result on a commit: a commit status or a check run.
+type Check struct {
+	Name  string `json:"name"`
+	State string `json:"state"` // "success", "failure", "pending" or "neutral"
+	URL   string `json:"url"`
+}
+
+// CI states of a branch head, as summarized by SummarizeChecks.
+const (
+	CIPassing = "passing"
+	CIFailing = "failing"
+	CIPending = "pending"
+)
+
+// SummarizeChecks reduces checks to one CI state: failing if any failed,
+// else pending if any are still running, else passing. Empty for no checks.
+func SummarizeChecks(checks []Check) string {
+	state := ""
+	for _, c := range checks {
+		switch c.State {
+		case "failure":
+			return CIFailing
+		case "pending":
+			state = CIPending
+		case "success", "neutral":
+			if state == "" {
+				state = CIPassing
+			}
+		}
+	}
+	return state
+}
+
+// ListChecks returns the commit statuses and check runs reported for ref
+// (a branch, tag or SHA).
+func (c *Client) ListChecks(ctx context.Context, owner, repo, ref string) ([]Check, error) {
+	var combined struct {
+		Statuses []struct {
+			Context   string `json:"context"`
+			State     string `json:"state"` // error, failure, pending, success
+			TargetURL string `json:"target_url"`
+		} `json:"statuses"`
+	}
+	ref = url.PathEscape(ref)
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, repo, ref), &combined); err != nil {
+		return nil, err
+	}
+	var runs struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`     // queued, in_progress, completed
+			Conclusion string `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+			HTMLURL    string `json:"html_url"`
+		} `json:"check_runs"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs?per_page=%d", owner, repo, ref, perPage), &runs); err != nil {
+		return nil, err
+	}
+
+	var checks []Check
+	for _, s := range combined.Statuses {
+		state := s.State
+		if state == "error" {
+			state = "failure"
+		}
+		checks = append(checks, Check{Name: s.Context, State: state, URL: s.TargetURL})
+	}
+	for _, r := range runs.CheckRuns {
+		state := "pending"
+		if r.Status == "completed" {
+			switch r.Conclusion {
+			case "success":
+				state = "success"
+			case "neutral", "skipped":
+				state = "neutral"
+			default:
+				state = "failure"
+			}
+		}
+		checks = append(checks, Check{Name: r.Name, State: state, URL: r.HTMLURL})
+	}
+	return checks, nil
+}
@@ -2,44 +2,292 @@ package github
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // CommitInfo holds short info about a single commit.
 type CommitInfo struct {
-	SHA     string    // 7-char short SHA
-	Message string    // first line of commit message
-	Date    time.Time // author date
+	SHA       string    `json:"sha"`                  // 7-char short SHA
+	Message   string    `json:"message"`              // first line of commit message
+	Body      string    `json:"body,omitempty"`       // rest of the message, trimmed
+	Author    Person    `json:"author"`               // who wrote it
+	Committer Person    `json:"committer"`            // who applied it (e.g. "web-flow" for merges made on GitHub)
+	CoAuthors []Person  `json:"co_authors,omitempty"` // from Co-authored-by trailers
+	Date      time.Time `json:"date"`                 // author date
 }
 
+// Authors returns the commit author followed by its co-authors.
+func (c CommitInfo) Authors() []Person {
+	return append([]Person{c.Author}, c.CoAuthors...)
+}
+
+// Person is a commit author or committer. Login is empty when the git email
+// isn't linked to a GitHub account.
+type Person struct {
+	Login string `json:"login,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// String returns "@login", or the git name when there is no login.
+func (p Person) String() string {
+	if p.Login != "" {
+		return "@" + p.Login
+	}
+	return p.Name
+}
+
+// key identifies a person across commits.
+func (p Person) key() string {
+	if p.Login != "" {
+		return "@" + strings.ToLower(p.Login)
+	}
+	return strings.ToLower(p.Name)
+}
+
+// coAuthorTrailer matches "Co-authored-by: Name <email>" lines.
+var coAuthorTrailer = regexp.MustCompile(`(?im)^co-authored-by:\s*(.+?)\s*<([^>]*)>\s*$`)
+
+// parseCoAuthors extracts Co-authored-by trailers from a commit message.
+// GitHub noreply addresses ("123+login@users.noreply.github.com") yield the login.
+func parseCoAuthors(msg string) []Person {
+	var out []Person
+	for _, m := range coAuthorTrailer.FindAllStringSubmatch(msg, -1) {
+		p := Person{Name: m[1]}
+		if local, ok := strings.CutSuffix(strings.ToLower(m[2]), "@users.noreply.github.com"); ok {
+			if _, login, found := strings.Cut(local, "+"); found {
+				p.Login = login
+			} else {
+				p.Login = local
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// InlineCommits is how many commits an expanded table row shows.
+const InlineCommits = 5
+
+// MaxCommits is how many commits the compare API returns at most.
+const MaxCommits = 250
+
 // RepoStatus holds the computed deploy status for a repo.
 type RepoStatus struct {
-	Owner        string
-	Repo         string
-	Branch       string
-	TagName      string       // latest tag or release name
-	RefType      string       // "release" or "tag"
-	CommitsAhead int          // commits on main since last tag/release
-	Commits      []CommitInfo // up to 5 most recent, newest first
-	Status       Status
-	ErrorMsg     string
-	LastChecked  time.Time
+	Owner        string        `json:"owner"`
+	Repo         string        `json:"repo"`
+	Branch       string        `json:"branch"`
+	TagName      string        `json:"tag_name"`            // latest tag or release name
+	RefType      string        `json:"ref_type"`            // "release" or "tag"
+	TagSHA       string        `json:"tag_sha,omitempty"`   // commit the tag/release points at
+	HeadSHA      string        `json:"head_sha,omitempty"`  // branch head at check time; empty past MaxCommits unreleased commits
+	CommitsAhead int           `json:"commits_ahead"`       // commits on main since last tag/release
+	BehindBy     int           `json:"behind_by,omitempty"` // commits in the tag/release that aren't on the branch
+	Commits      []CommitInfo  `json:"commits"`             // unreleased commits, newest first (the API caps at MaxCommits)
+	ReleasedAt   time.Time     `json:"released_at"`         // when the latest tag/release was cut
+	Oldest       time.Time     `json:"oldest"`              // author date of the oldest unreleased commit
+	Diff         DiffStat      `json:"diff"`                // size of the unreleased change
+	CI           string        `json:"ci,omitempty"`        // CIPassing, CIFailing or CIPending; empty when no checks report
+	Risks        []string      `json:"risks,omitempty"`     // names of matched risk rules, set by the checker
+	Status       Status        `json:"status"`
+	ErrorMsg     string        `json:"error,omitempty"`
+	LastChecked  time.Time     `json:"last_checked"`
+	Duration     time.Duration `json:"check_duration"` // wall time of the check
+}
+
+// Brief returns s with only the InlineCommits newest commits and without the
+// changed-file list, for state.json and the repo list API, where the full
+// lists would be kept for every repo.
+func (s RepoStatus) Brief() RepoStatus {
+	if len(s.Commits) > InlineCommits {
+		s.Commits = s.Commits[:InlineCommits:InlineCommits]
+	}
+	s.Diff.Files = nil
+	return s
+}
+
+// Partial reports whether Commits or Diff.Files was cut by Brief, so a fresh
+// check would return more of them.
+func (s RepoStatus) Partial() bool {
+	return len(s.Commits) < min(s.CommitsAhead, MaxCommits) || len(s.Diff.Files) < s.Diff.FileCount
+}
+
+// FullName returns "owner/repo".
+func (s RepoStatus) FullName() string {
+	return s.Owner + "/" + s.Repo
+}
+
+// URL links to the repo on GitHub.
+func (s RepoStatus) URL() string {
+	return "https://github.com/" + s.FullName()
+}
+
+// CompareURL links to the tag...branch compare view, or to the repo when
+// there's no tag yet.
+func (s RepoStatus) CompareURL() string {
+	if s.TagName == "" || s.Branch == "" {
+		return s.URL()
+	}
+	return fmt.Sprintf("%s/compare/%s...%s", s.URL(), s.TagName, s.Branch)
+}
+
+// ReleaseURL links to the latest release (or tag) page, or to the list of
+// releases when there is none.
+func (s RepoStatus) ReleaseURL() string {
+	if s.TagName == "" {
+		return s.URL() + "/releases"
+	}
+	return s.URL() + "/releases/tag/" + s.TagName
+}
+
+// CommitURL links to a commit of the repo; sha may be abbreviated.
+func (s RepoStatus) CommitURL(sha string) string {
+	return s.URL() + "/commit/" + sha
+}
+
+// FileDiffURL links to path's diff within the compare view.
+func (s RepoStatus) FileDiffURL(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return s.CompareURL() + "#diff-" + hex.EncodeToString(sum[:])
+}
+
+// UnreleasedAge returns how long the oldest unreleased commit has been waiting.
+// Zero when there is nothing unreleased.
+func (s RepoStatus) UnreleasedAge(now time.Time) time.Duration {
+	if s.CommitsAhead == 0 || s.Oldest.IsZero() {
+		return 0
+	}
+	return now.Sub(s.Oldest)
+}
+
+// DiffStat summarizes the files changed between the release and the branch head.
+type DiffStat struct {
+	Files     []FileChange `json:"files,omitempty"` // most changed first
+	FileCount int          `json:"file_count"`      // len(Files) as checked; Brief drops Files
+	Additions int          `json:"additions"`
+	Deletions int          `json:"deletions"`
+	Truncated bool         `json:"truncated,omitempty"` // the API stops listing files at maxCompareFiles, so the totals are a lower bound
+	TopDirs   []DirStat    `json:"top_dirs,omitempty"`  // most changed top-level directories first
+}
+
+// FileChange is one file in a compare.
+type FileChange struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"` // added, removed, modified, renamed, ...
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// DirStat is the lines changed under a top-level directory ("/" for files at the root).
+type DirStat struct {
+	Dir     string `json:"dir"`
+	Changes int    `json:"changes"`
+}
+
+// maxCompareFiles is how many files the compare API lists.
+const maxCompareFiles = 300
+
+// topDirs is how many directories DiffStat.TopDirs keeps.
+const topDirs = 5
+
+func newDiffStat(files []FileChange) DiffStat {
+	d := DiffStat{Files: files, FileCount: len(files), Truncated: len(files) >= maxCompareFiles}
+	byDir := make(map[string]int)
+	for _, f := range files {
+		d.Additions += f.Additions
+		d.Deletions += f.Deletions
+		dir := "/"
+		if i := strings.Index(f.Path, "/"); i > 0 {
+			dir = f.Path[:i+1]
+		}
+		byDir[dir] += f.Additions + f.Deletions
+	}
+	sort.SliceStable(d.Files, func(i, j int) bool {
+		return d.Files[i].Additions+d.Files[i].Deletions > d.Files[j].Additions+d.Files[j].Deletions
+	})
+	for dir, n := range byDir {
+		d.TopDirs = append(d.TopDirs, DirStat{Dir: dir, Changes: n})
+	}
+	sort.Slice(d.TopDirs, func(i, j int) bool {
+		if d.TopDirs[i].Changes != d.TopDirs[j].Changes {
+			return d.TopDirs[i].Changes > d.TopDirs[j].Changes
+		}
+		return d.TopDirs[i].Dir < d.TopDirs[j].Dir
+	})
+	if len(d.TopDirs) > topDirs {
+		d.TopDirs = d.TopDirs[:topDirs]
+	}
+	return d
+}
+
+// NeedsDeploy reports whether the branch has unreleased commits, whatever its
+// CI state or whether the release diverged from it.
+func (s RepoStatus) NeedsDeploy() bool {
+	return s.Status == StatusBehind || s.Status == StatusBroken || (s.Status == StatusDiverged && s.CommitsAhead > 0)
+}
+
+// Divergence explains a StatusDiverged result, e.g.
+// "v1.4.1 has 2 commit(s) not on main"; empty for any other status.
+func (s RepoStatus) Divergence() string {
+	if s.Status != StatusDiverged {
+		return ""
+	}
+	return fmt.Sprintf("%s has %d commit(s) not on %s", s.TagName, s.BehindBy, s.Branch)
+}
+
+// Authors returns everyone who authored or co-authored an unreleased commit,
+// most commits first.
+func (s RepoStatus) Authors() []Person {
+	counts := make(map[string]int)
+	var people []Person
+	for _, c := range s.Commits {
+		seen := make(map[string]bool)
+		for _, p := range c.Authors() {
+			k := p.key()
+			if k == "" || seen[k] {
+				continue
+			}
+			seen[k] = true
+			if counts[k] == 0 {
+				people = append(people, p)
+			}
+			counts[k]++
+		}
+	}
+	sort.SliceStable(people, func(i, j int) bool { return counts[people[i].key()] > counts[people[j].key()] })
+	return people
+}
+
+// DaysSinceRelease returns the whole days since the latest tag/release, or -1 if unknown.
+func (s RepoStatus) DaysSinceRelease(now time.Time) int {
+	if s.ReleasedAt.IsZero() {
+		return -1
+	}
+	return int(now.Sub(s.ReleasedAt).Hours() / 24)
 }
 
 type Status int
 
 const (
-	StatusLoading Status = iota
-	StatusClean          // up to date
-	StatusBehind         // has unreleased commits
-	StatusNoRelease      // no tags/releases yet
+	StatusLoading   Status = iota
+	StatusClean            // up to date
+	StatusBehind           // has unreleased commits
+	StatusNoRelease        // no tags/releases yet
 	StatusError
+	StatusBroken   // has unreleased commits but CI on the branch head is failing
+	StatusDiverged // the tag/release has commits the branch doesn't (e.g. cut from an unmerged hotfix branch); CI may also be failing
 )
 
 func (s Status) String() string {
@@ -54,14 +302,66 @@ func (s Status) String() string {
 		return "no_release"
 	case StatusError:
 		return "error"
+	case StatusBroken:
+		return "broken"
+	case StatusDiverged:
+		return "diverged"
 	}
 	return "unknown"
 }
 
+// MarshalText encodes a Status by name so persisted results stay readable.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Status) UnmarshalText(b []byte) error {
+	for st := StatusLoading; st <= StatusDiverged; st++ {
+		if st.String() == string(b) {
+			*s = st
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown status %q", b)
+}
+
 // Client handles GitHub API requests.
 type Client struct {
 	token      string
 	httpClient *http.Client
+
+	mu        sync.Mutex
+	rateLimit RateLimit
+	passing   map[string]string // "owner/repo" → last head SHA whose CI passed
+}
+
+// RateLimit is the API quota reported by the most recent response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimit returns the last observed API quota; zero until the first request.
+func (c *Client) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
+func (c *Client) recordRateLimit(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	rl := RateLimit{Remaining: remaining}
+	rl.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	c.mu.Lock()
+	c.rateLimit = rl
+	c.mu.Unlock()
 }
 
 // New creates a new GitHub client. Tries GITHUB_TOKEN env var first, then gh CLI.
@@ -110,6 +410,7 @@ func (c *Client) get(ctx context.Context, path string, v any) error {
 		return err
 	}
 	defer resp.Body.Close()
+	c.recordRateLimit(resp.Header)
 
 	if resp.StatusCode == 404 {
 		return ErrNotFound
@@ -141,7 +442,7 @@ func (c *Client) CheckRepo(ctx context.Context, owner, repo string) RepoStatus {
 	result.Branch = branch
 
 	// 2. Try latest release, fall back to latest tag
-	refSHA, refName, refType, err := c.getLatestRef(ctx, owner, repo)
+	refSHA, refName, refType, publishedAt, err := c.getLatestRef(ctx, owner, repo)
 	if err != nil {
 		result.Status = StatusError
 		result.ErrorMsg = shortErr(err)
@@ -154,26 +455,79 @@ func (c *Client) CheckRepo(ctx context.Context, owner, repo string) RepoStatus {
 
 	result.TagName = refName
 	result.RefType = refType
+	result.TagSHA = refSHA
 
 	// 3. Compare ref..branch
-	ahead, commits, err := c.compareCommits(ctx, owner, repo, refSHA, branch)
+	cmp, err := c.compareCommits(ctx, owner, repo, refSHA, branch)
 	if err != nil {
 		result.Status = StatusError
 		result.ErrorMsg = shortErr(err)
 		return result
 	}
 
-	result.CommitsAhead = ahead
-	result.Commits = commits
-	if ahead > 0 {
+	result.CommitsAhead = cmp.aheadBy
+	result.BehindBy = cmp.behindBy
+	result.Commits = cmp.commits
+	result.HeadSHA = cmp.headSHA
+	result.Diff = cmp.diff
+	result.Oldest = cmp.oldest
+	// Releases carry a publish date; plain tags are dated by their commit.
+	result.ReleasedAt = publishedAt
+	if result.ReleasedAt.IsZero() {
+		result.ReleasedAt = cmp.baseDate
+	}
+	// CI is informational; a failure to fetch it doesn't fail the check.
+	result.CI = c.ciStatus(ctx, owner, repo, cmp.headSHA, branch)
+
+	switch {
+	case cmp.behindBy > 0 || cmp.status == "diverged" || cmp.status == "behind":
+		// Deploying the branch would drop whatever only the release has. That
+		// outranks red CI, which the table and summaries still show alongside.
+		result.Status = StatusDiverged
+	case cmp.aheadBy > 0 && result.CI == CIFailing:
+		result.Status = StatusBroken
+	case cmp.aheadBy > 0:
 		result.Status = StatusBehind
-	} else {
+	default:
 		result.Status = StatusClean
 	}
 
 	return result
 }
 
+// ciStatus summarizes CI on the branch head. A head whose CI passed is
+// remembered, so re-checking an unchanged repo costs no CI requests; failing
+// and pending results are fetched again, since re-runs change them. Without a
+// head SHA the branch is looked up by name and nothing is remembered.
+func (c *Client) ciStatus(ctx context.Context, owner, repo, headSHA, branch string) string {
+	key := owner + "/" + repo
+	c.mu.Lock()
+	cached := headSHA != "" && c.passing[key] == headSHA
+	c.mu.Unlock()
+	if cached {
+		return CIPassing
+	}
+
+	ref := headSHA
+	if ref == "" {
+		ref = branch
+	}
+	checks, err := c.ListChecks(ctx, owner, repo, ref)
+	if err != nil {
+		return ""
+	}
+	ci := SummarizeChecks(checks)
+	if ci == CIPassing && headSHA != "" {
+		c.mu.Lock()
+		if c.passing == nil {
+			c.passing = make(map[string]string)
+		}
+		c.passing[key] = headSHA
+		c.mu.Unlock()
+	}
+	return ci
+}
+
 func (c *Client) getDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
 	var r struct {
 		DefaultBranch string `json:"default_branch"`
@@ -187,15 +541,16 @@ func (c *Client) getDefaultBranch(ctx context.Context, owner, repo string) (stri
 	return r.DefaultBranch, nil
 }
 
-func (c *Client) getLatestRef(ctx context.Context, owner, repo string) (sha, name, refType string, err error) {
+func (c *Client) getLatestRef(ctx context.Context, owner, repo string) (sha, name, refType string, publishedAt time.Time, err error) {
 	// Try release first
 	var release struct {
-		TagName string `json:"tag_name"`
+		TagName     string    `json:"tag_name"`
+		PublishedAt time.Time `json:"published_at"`
 	}
 	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/releases/latest", owner, repo), &release); err == nil && release.TagName != "" {
 		sha, err := c.resolveTagSHA(ctx, owner, repo, release.TagName)
 		if err == nil {
-			return sha, release.TagName, "release", nil
+			return sha, release.TagName, "release", release.PublishedAt, nil
 		}
 	}
 
@@ -207,10 +562,10 @@ func (c *Client) getLatestRef(ctx context.Context, owner, repo string) (sha, nam
 		} `json:"commit"`
 	}
 	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/tags?per_page=1", owner, repo), &tags); err != nil {
-		return "", "", "", err
+		return "", "", "", time.Time{}, err
 	}
 	if len(tags) == 0 {
-		return "", "", "", nil // no tags at all
+		return "", "", "", time.Time{}, nil // no tags at all
 	}
 
 	tag := tags[0]
@@ -220,7 +575,7 @@ func (c *Client) getLatestRef(ctx context.Context, owner, repo string) (sha, nam
 		sha = resolved
 	}
 
-	return sha, tag.Name, "tag", nil
+	return sha, tag.Name, "tag", time.Time{}, nil
 }
 
 func (c *Client) resolveTagSHA(ctx context.Context, owner, repo, tag string) (string, error) {
@@ -250,49 +605,355 @@ func (c *Client) resolveTagSHA(ctx context.Context, owner, repo, tag string) (st
 	return ref.Object.SHA, nil
 }
 
-func (c *Client) compareCommits(ctx context.Context, owner, repo, base, head string) (int, []CommitInfo, error) {
+// comparison is the digested result of a base...head compare.
+type comparison struct {
+	aheadBy  int
+	behindBy int          // commits in base that aren't in head
+	status   string       // "ahead", "behind", "diverged" or "identical"
+	commits  []CommitInfo // newest first
+	headSHA  string       // full SHA of head; empty when the API cut the commit list short
+	diff     DiffStat
+	oldest   time.Time // author date of the oldest commit in head not in base
+	baseDate time.Time // committer date of the base commit
+}
+
+func (c *Client) compareCommits(ctx context.Context, owner, repo, base, head string) (*comparison, error) {
 	var cmp struct {
-		AheadBy int `json:"ahead_by"`
+		AheadBy    int    `json:"ahead_by"`
+		BehindBy   int    `json:"behind_by"`
+		Status     string `json:"status"`
+		BaseCommit struct {
+			SHA    string `json:"sha"`
+			Commit struct {
+				Committer struct {
+					Date time.Time `json:"date"`
+				} `json:"committer"`
+			} `json:"commit"`
+		} `json:"base_commit"`
+		MergeBaseCommit struct {
+			SHA string `json:"sha"`
+		} `json:"merge_base_commit"`
 		Commits []struct {
 			SHA    string `json:"sha"`
 			Commit struct {
 				Message string `json:"message"`
 				Author  struct {
+					Name string    `json:"name"`
 					Date time.Time `json:"date"`
 				} `json:"author"`
+				Committer struct {
+					Name string `json:"name"`
+				} `json:"committer"`
 			} `json:"commit"`
+			// null when the email isn't linked to an account
+			Author *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Committer *struct {
+				Login string `json:"login"`
+			} `json:"committer"`
 		} `json:"commits"`
+		Files []struct {
+			Filename  string `json:"filename"`
+			Status    string `json:"status"`
+			Additions int    `json:"additions"`
+			Deletions int    `json:"deletions"`
+		} `json:"files"`
 	}
 	path := fmt.Sprintf("/repos/%s/%s/compare/%s...%s", owner, repo, base, head)
 	if err := c.get(ctx, path, &cmp); err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
-	// Take up to 5 most recent commits (API returns oldest first, so take from end)
-	const maxCommits = 5
-	all := cmp.Commits
-	start := 0
-	if len(all) > maxCommits {
-		start = len(all) - maxCommits
+	files := make([]FileChange, len(cmp.Files))
+	for i, f := range cmp.Files {
+		files[i] = FileChange{Path: f.Filename, Status: f.Status, Additions: f.Additions, Deletions: f.Deletions}
 	}
-	recent := all[start:]
 
-	// Reverse so newest is first
-	commits := make([]CommitInfo, len(recent))
-	for i, c := range recent {
+	// API returns oldest first; reverse so newest is first
+	all := cmp.Commits
+	commits := make([]CommitInfo, len(all))
+	for i, c := range all {
 		sha := c.SHA
 		if len(sha) > 7 {
 			sha = sha[:7]
 		}
-		// Only first line of commit message
-		msg := c.Commit.Message
-		if idx := strings.Index(msg, "\n"); idx != -1 {
-			msg = msg[:idx]
+		msg, body, _ := strings.Cut(c.Commit.Message, "\n")
+		author := Person{Name: c.Commit.Author.Name}
+		if c.Author != nil {
+			author.Login = c.Author.Login
+		}
+		committer := Person{Name: c.Commit.Committer.Name}
+		if c.Committer != nil {
+			committer.Login = c.Committer.Login
+		}
+		commits[len(all)-1-i] = CommitInfo{
+			SHA:       sha,
+			Message:   msg,
+			Body:      strings.TrimSpace(body),
+			Author:    author,
+			Committer: committer,
+			CoAuthors: parseCoAuthors(body),
+			Date:      c.Commit.Author.Date,
+		}
+	}
+
+	out := &comparison{
+		aheadBy:  cmp.AheadBy,
+		behindBy: cmp.BehindBy,
+		status:   cmp.Status,
+		commits:  commits,
+		diff:     newDiffStat(files),
+		baseDate: cmp.BaseCommit.Commit.Committer.Date,
+	}
+	switch {
+	case cmp.AheadBy == 0:
+		// Nothing ahead: the branch head is the merge base, not the tag commit.
+		out.headSHA = cmp.MergeBaseCommit.SHA
+	case len(all) == cmp.AheadBy:
+		out.headSHA = all[len(all)-1].SHA
+	}
+	// API returns oldest first (capped at 250), so the first entry is the oldest we can see
+	if len(all) > 0 {
+		out.oldest = all[0].Commit.Author.Date
+	}
+	return out, nil
+}
+
+// Release is a published release, or a plain tag dated by its commit.
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	URL         string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+	Prerelease  bool      `json:"prerelease"`
+	Draft       bool      `json:"draft"`
+}
+
+// ReleaseHistory returns up to n most recent releases, newest first, skipping
+// drafts. Repos that only push tags fall back to tags dated by their commit.
+func (c *Client) ReleaseHistory(ctx context.Context, owner, repo string, n int) ([]Release, error) {
+	var all []Release
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/releases?per_page=%d", owner, repo, n), &all); err != nil {
+		return nil, err
+	}
+	releases := all[:0]
+	for _, r := range all {
+		if !r.Draft && !r.PublishedAt.IsZero() {
+			releases = append(releases, r)
 		}
-		commits[len(recent)-1-i] = CommitInfo{SHA: sha, Message: msg, Date: c.Commit.Author.Date}
+	}
+	if len(releases) > 0 {
+		return releases, nil
 	}
 
-	return cmp.AheadBy, commits, nil
+	var tags []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/tags?per_page=%d", owner, repo, n), &tags); err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		var commit struct {
+			Commit struct {
+				Committer struct {
+					Date time.Time `json:"date"`
+				} `json:"committer"`
+			} `json:"commit"`
+		}
+		if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s", owner, repo, t.Commit.SHA), &commit); err != nil {
+			return nil, err
+		}
+		releases = append(releases, Release{
+			TagName:     t.Name,
+			URL:         fmt.Sprintf("https://github.com/%s/%s/tree/%s", owner, repo, t.Name),
+			PublishedAt: commit.Commit.Committer.Date,
+		})
+	}
+	// Tags come back in name order; sort by date so intervals make sense.
+	sort.Slice(releases, func(i, j int) bool { return releases[i].PublishedAt.After(releases[j].PublishedAt) })
+	return releases, nil
+}
+
+// CommitDates returns the author dates of every commit in base...head (capped at 250 by the API).
+func (c *Client) CommitDates(ctx context.Context, owner, repo, base, head string) ([]time.Time, error) {
+	var cmp struct {
+		Commits []struct {
+			Commit struct {
+				Author struct {
+					Date time.Time `json:"date"`
+				} `json:"author"`
+			} `json:"commit"`
+		} `json:"commits"`
+	}
+	path := fmt.Sprintf("/repos/%s/%s/compare/%s...%s", owner, repo, base, head)
+	if err := c.get(ctx, path, &cmp); err != nil {
+		return nil, err
+	}
+	dates := make([]time.Time, len(cmp.Commits))
+	for i, c := range cmp.Commits {
+		dates[i] = c.Commit.Author.Date
+	}
+	return dates, nil
+}
+
+// Repository is a repo as returned by the listing endpoints.
+type Repository struct {
+	Owner       string
+	Name        string
+	Description string
+	Archived    bool
+	Topics      []string
+}
+
+// RepoFilter selects repositories to list. Either Org or Query is required:
+// Org lists an organization (or user), optionally narrowed to a Team's repos;
+// Query runs a GitHub repository search instead. Topic, ExcludeArchived and
+// Match (a regexp on the repo name) are applied to the listing.
+type RepoFilter struct {
+	Org             string
+	Team            string
+	Query           string
+	Topic           string
+	ExcludeArchived bool
+	Match           string
+}
+
+// ListRepos lists every repo matching the filter, following pagination.
+// Org may also be a user account.
+func (c *Client) ListRepos(ctx context.Context, f RepoFilter) ([]Repository, error) {
+	if f.Org == "" && f.Query == "" {
+		return nil, fmt.Errorf("org or query is required")
+	}
+	var match *regexp.Regexp
+	if f.Match != "" {
+		var err error
+		if match, err = regexp.Compile(f.Match); err != nil {
+			return nil, fmt.Errorf("invalid match pattern: %w", err)
+		}
+	}
+
+	var all []Repository
+	var err error
+	switch {
+	case f.Query != "":
+		all, err = c.searchRepos(ctx, f.Query)
+	case f.Team != "":
+		all, err = c.listRepoPages(ctx, fmt.Sprintf("/orgs/%s/teams/%s/repos", f.Org, f.Team))
+	default:
+		all, err = c.listRepoPages(ctx, fmt.Sprintf("/orgs/%s/repos?type=all", f.Org))
+	}
+	if err == ErrNotFound && f.Query == "" && f.Team == "" {
+		// Not an org — try it as a user account.
+		all, err = c.listRepoPages(ctx, fmt.Sprintf("/users/%s/repos?type=owner", f.Org))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Repository
+	for _, r := range all {
+		if f.ExcludeArchived && r.Archived {
+			continue
+		}
+		if f.Topic != "" && !hasTopic(r.Topics, f.Topic) {
+			continue
+		}
+		if match != nil && !match.MatchString(r.Name) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// GetRepo fetches a single repository. It returns ErrNotFound when the repo
+// doesn't exist or the token can't see it.
+func (c *Client) GetRepo(ctx context.Context, owner, repo string) (*Repository, error) {
+	var r apiRepo
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s", owner, repo), &r); err != nil {
+		return nil, err
+	}
+	out := r.toRepository()
+	return &out, nil
+}
+
+// apiRepo is the repository shape shared by the listing and search endpoints.
+type apiRepo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Archived    bool     `json:"archived"`
+	Topics      []string `json:"topics"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+func (r apiRepo) toRepository() Repository {
+	return Repository{
+		Owner:       r.Owner.Login,
+		Name:        r.Name,
+		Description: r.Description,
+		Archived:    r.Archived,
+		Topics:      r.Topics,
+	}
+}
+
+const perPage = 100
+
+func (c *Client) listRepoPages(ctx context.Context, base string) ([]Repository, error) {
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+
+	var out []Repository
+	for page := 1; ; page++ {
+		var batch []apiRepo
+		if err := c.get(ctx, fmt.Sprintf("%s%sper_page=%d&page=%d", base, sep, perPage, page), &batch); err != nil {
+			return nil, err
+		}
+		for _, r := range batch {
+			out = append(out, r.toRepository())
+		}
+		if len(batch) < perPage {
+			return out, nil
+		}
+	}
+}
+
+// searchRepos runs a repository search. GitHub caps search results at 1000.
+func (c *Client) searchRepos(ctx context.Context, query string) ([]Repository, error) {
+	var out []Repository
+	for page := 1; page <= 10; page++ {
+		var res struct {
+			Items []apiRepo `json:"items"`
+		}
+		path := fmt.Sprintf("/search/repositories?q=%s&per_page=%d&page=%d", url.QueryEscape(query), perPage, page)
+		if err := c.get(ctx, path, &res); err != nil {
+			return nil, err
+		}
+		for _, r := range res.Items {
+			out = append(out, r.toRepository())
+		}
+		if len(res.Items) < perPage {
+			break
+		}
+	}
+	return out, nil
+}
+
+func hasTopic(topics []string, want string) bool {
+	for _, t := range topics {
+		if strings.EqualFold(t, want) {
+			return true
+		}
+	}
+	return false
 }
 
 func shortErr(err error) string {
@@ -0,0 +1,185 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Record is one persisted check result.
+type Record struct {
+	Time         time.Time     `json:"time"`
+	Tag          string        `json:"tag"`
+	CommitsAhead int           `json:"commits_ahead"`
+	Status       github.Status `json:"status"`
+}
+
+// Store is a bbolt-backed log of check results, one bucket per "owner/repo",
+// keyed by check time.
+//
+// The database is opened per operation rather than held open, because bbolt
+// takes an exclusive file lock and the TUI and daemon may run side by side.
+type Store struct {
+	path string
+	mu   sync.Mutex // serialises opens within this process; the file lock covers others
+}
+
+// DefaultPath returns the default history database path (~/.config/reprac/history.db).
+func DefaultPath() string {
+	return filepath.Join(config.Dir(), "history.db")
+}
+
+func Open(path string) *Store {
+	return &Store{path: path}
+}
+
+func (s *Store) update(fn func(tx *bolt.Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("creating history dir: %w", err)
+	}
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return fmt.Errorf("opening history: %w", err)
+	}
+	defer db.Close()
+	return db.Update(fn)
+}
+
+func (s *Store) view(fn func(tx *bolt.Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return nil // nothing recorded yet
+	}
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{Timeout: 2 * time.Second, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("opening history: %w", err)
+	}
+	defer db.Close()
+	return db.View(fn)
+}
+
+// Add appends check results. Results still loading are skipped.
+func (s *Store) Add(results ...github.RepoStatus) error {
+	return s.update(func(tx *bolt.Tx) error {
+		for _, r := range results {
+			if r.Status == github.StatusLoading || r.LastChecked.IsZero() {
+				continue
+			}
+			b, err := tx.CreateBucketIfNotExists([]byte(r.FullName()))
+			if err != nil {
+				return err
+			}
+			v, err := json.Marshal(Record{
+				Time:         r.LastChecked,
+				Tag:          r.TagName,
+				CommitsAhead: r.CommitsAhead,
+				Status:       r.Status,
+			})
+			if err != nil {
+				return err
+			}
+			if err := b.Put(timeKey(r.LastChecked), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Query returns a repo's records since the given time, oldest first.
+func (s *Store) Query(repo string, since time.Time) ([]Record, error) {
+	var out []Record
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(repo))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Seek(timeKey(since)); k != nil; k, v = c.Next() {
+			var rec Record
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return fmt.Errorf("decoding history for %s: %w", repo, err)
+			}
+			out = append(out, rec)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// timeKey encodes t so byte order matches chronological order.
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano()))
+	return k
+}
+
+// Daily buckets records into the last `days` calendar days (oldest first),
+// taking the highest commits-ahead seen each day. Days without a check carry
+// the previous value forward; days before the first record are -1.
+func Daily(records []Record, days int, now time.Time) []int {
+	out := make([]int, days)
+	for i := range out {
+		out[i] = -1
+	}
+	today := calendarDay(now)
+	for _, r := range records {
+		idx := days - 1 - int(today.Sub(calendarDay(r.Time)).Hours()/24)
+		if idx < 0 || idx >= days {
+			continue
+		}
+		if r.CommitsAhead > out[idx] {
+			out[idx] = r.CommitsAhead
+		}
+	}
+	for i := 1; i < days; i++ {
+		if out[i] == -1 {
+			out[i] = out[i-1]
+		}
+	}
+	return out
+}
+
+// calendarDay returns t's local date as midnight UTC, so subtracting two of
+// them gives whole days even across DST changes.
+func calendarDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as block characters scaled to the largest value.
+// Negative values (no data) render as spaces.
+func Sparkline(values []int) string {
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		switch {
+		case v < 0:
+			sb.WriteRune(' ')
+		case max == 0:
+			sb.WriteRune(sparkBlocks[0])
+		default:
+			sb.WriteRune(sparkBlocks[v*(len(sparkBlocks)-1)/max])
+		}
+	}
+	return sb.String()
+}
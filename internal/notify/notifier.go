@@ -0,0 +1,177 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"text/template"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+)
+
+// Built-in webhook payloads. Slack's incoming webhooks and most chat tools
+// accept {"text": ...}; Teams wants a MessageCard.
+var builtinTemplates = map[string]string{
+	"slack": `{"text": {{json (printf "*%s*\n%s\n%s" .Title .Text .URL)}}}`,
+	"teams": `{"@type": "MessageCard", "@context": "https://schema.org/extensions", ` +
+		`"summary": {{json .Title}}, "title": {{json .Title}}, "text": {{json .Text}}, ` +
+		`"potentialAction": [{"@type": "OpenUri", "name": "Open on GitHub", "targets": [{"os": "default", "uri": {{json .URL}}}]}]}`,
+}
+
+// payloadData is what webhook templates are executed against.
+type payloadData struct {
+	Kind         string
+	Repo         string
+	Title        string
+	Text         string
+	URL          string
+	Status       string
+	Branch       string
+	Tag          string
+	CommitsAhead int
+	Error        string
+}
+
+type webhook struct {
+	cfg  config.WebhookConfig
+	tmpl *template.Template
+}
+
+// Notifier delivers events to the sinks configured under `notify:`.
+type Notifier struct {
+	desktop    bool
+	threshold  int
+	webhooks   []webhook
+	httpClient *http.Client
+}
+
+// New builds a Notifier, parsing every webhook template up front so typos
+// surface at startup rather than on the first transition.
+func New(cfg config.NotifyConfig) (*Notifier, error) {
+	n := &Notifier{
+		desktop:    cfg.Desktop,
+		threshold:  cfg.BehindThreshold,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	funcs := template.FuncMap{"json": toJSON}
+	for i, wh := range cfg.Webhooks {
+		if wh.URL == "" {
+			return nil, fmt.Errorf("notify.webhooks[%d]: url is required", i)
+		}
+		body := wh.Payload
+		if body == "" {
+			name := wh.Template
+			if name == "" {
+				name = "slack"
+			}
+			var ok bool
+			if body, ok = builtinTemplates[name]; !ok {
+				return nil, fmt.Errorf("notify.webhooks[%d]: unknown template %q (want slack or teams)", i, name)
+			}
+		}
+		t, err := template.New(wh.URL).Funcs(funcs).Parse(body)
+		if err != nil {
+			return nil, fmt.Errorf("notify.webhooks[%d]: parsing payload: %w", i, err)
+		}
+		n.webhooks = append(n.webhooks, webhook{cfg: wh, tmpl: t})
+	}
+	return n, nil
+}
+
+// Enabled reports whether any sink is configured.
+func (n *Notifier) Enabled() bool {
+	return n != nil && (n.desktop || len(n.webhooks) > 0)
+}
+
+// Threshold returns the configured behind_threshold (0 = disabled).
+func (n *Notifier) Threshold() int {
+	if n == nil {
+		return 0
+	}
+	return n.threshold
+}
+
+// Send delivers every event to every sink. Failures don't stop other
+// deliveries; they are joined into the returned error.
+func (n *Notifier) Send(ctx context.Context, events []Event) error {
+	if !n.Enabled() {
+		return nil
+	}
+	var errs []error
+	for _, e := range events {
+		if n.desktop {
+			if err := sendDesktop(e.Title(), e.Text()); err != nil {
+				errs = append(errs, fmt.Errorf("desktop: %w", err))
+			}
+		}
+		for _, wh := range n.webhooks {
+			if err := n.post(ctx, wh, e); err != nil {
+				errs = append(errs, fmt.Errorf("webhook %s: %w", wh.cfg.URL, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) post(ctx context.Context, wh webhook, e Event) error {
+	data := payloadData{
+		Kind:         e.Kind.String(),
+		Repo:         e.Repo(),
+		Title:        e.Title(),
+		Text:         e.Text(),
+		URL:          e.URL(),
+		Status:       e.Current.Status.String(),
+		Branch:       e.Current.Branch,
+		Tag:          e.Current.TagName,
+		CommitsAhead: e.Current.CommitsAhead,
+		Error:        e.Current.ErrorMsg,
+	}
+	var body bytes.Buffer
+	if err := wh.tmpl.Execute(&body, data); err != nil {
+		return fmt.Errorf("rendering payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", wh.cfg.URL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range wh.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func sendDesktop(title, body string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", toJSON(body), toJSON(title))
+		return exec.Command("osascript", "-e", script).Run()
+	default:
+		return exec.Command("notify-send", "--app-name=reprac", title, body).Run()
+	}
+}
+
+// toJSON quotes a value for safe embedding in a JSON payload template.
+func toJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return `""`
+	}
+	return string(b)
+}
@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+// request is what the stand-in webhook received.
+type request struct {
+	method, contentType, auth string
+	body                      []byte
+}
+
+func standIn(t *testing.T) (*httptest.Server, <-chan request) {
+	t.Helper()
+	got := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- request{r.Method, r.Header.Get("Content-Type"), r.Header.Get("Authorization"), body}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func behindEvent() Event {
+	return Event{
+		Kind:     KindBehind,
+		Previous: github.RepoStatus{Owner: "org", Repo: "app", Status: github.StatusClean},
+		Current: github.RepoStatus{
+			Owner: "org", Repo: "app", Branch: "main", TagName: "v1.2.0",
+			CommitsAhead: 3, Status: github.StatusBehind,
+		},
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	e := behindEvent()
+	tests := []struct {
+		name  string
+		hook  config.WebhookConfig
+		check func(t *testing.T, body map[string]any)
+	}{
+		{
+			name: "slack",
+			hook: config.WebhookConfig{Template: "slack"},
+			check: func(t *testing.T, body map[string]any) {
+				want := "*" + e.Title() + "*\n" + e.Text() + "\n" + e.URL()
+				if body["text"] != want {
+					t.Errorf("text = %q, want %q", body["text"], want)
+				}
+			},
+		},
+		{
+			name: "teams",
+			hook: config.WebhookConfig{Template: "teams"},
+			check: func(t *testing.T, body map[string]any) {
+				if body["@type"] != "MessageCard" || body["title"] != e.Title() || body["text"] != e.Text() {
+					t.Errorf("unexpected card: %v", body)
+				}
+				actions, _ := body["potentialAction"].([]any)
+				if len(actions) != 1 {
+					t.Fatalf("potentialAction = %v", body["potentialAction"])
+				}
+				targets := actions[0].(map[string]any)["targets"].([]any)
+				if uri := targets[0].(map[string]any)["uri"]; uri != e.URL() {
+					t.Errorf("uri = %v, want %s", uri, e.URL())
+				}
+			},
+		},
+		{
+			name: "custom",
+			hook: config.WebhookConfig{
+				Payload: `{"repo": {{json .Repo}}, "kind": {{json .Kind}}, "ahead": {{.CommitsAhead}}, "tag": {{json .Tag}}}`,
+				Headers: map[string]string{"Authorization": "Bearer secret"},
+			},
+			check: func(t *testing.T, body map[string]any) {
+				if body["repo"] != "org/app" || body["kind"] != "behind" || body["ahead"] != 3.0 || body["tag"] != "v1.2.0" {
+					t.Errorf("unexpected body: %v", body)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, got := standIn(t)
+			tt.hook.URL = srv.URL
+			n, err := New(config.NotifyConfig{Webhooks: []config.WebhookConfig{tt.hook}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Send(context.Background(), []Event{e}); err != nil {
+				t.Fatal(err)
+			}
+			r := <-got
+			if r.method != http.MethodPost {
+				t.Errorf("method = %s, want POST", r.method)
+			}
+			if r.contentType != "application/json" {
+				t.Errorf("content type = %q, want application/json", r.contentType)
+			}
+			if want := tt.hook.Headers["Authorization"]; r.auth != want {
+				t.Errorf("Authorization = %q, want %q", r.auth, want)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(r.body, &body); err != nil {
+				t.Fatalf("body is not JSON: %v\n%s", err, r.body)
+			}
+			tt.check(t, body)
+		})
+	}
+}
+
+func TestSendWebhookHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	n, err := New(config.NotifyConfig{Webhooks: []config.WebhookConfig{{URL: srv.URL}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Send(context.Background(), []Event{behindEvent()}); err == nil {
+		t.Error("Send succeeded against a failing webhook")
+	}
+}
@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+// Kind is the type of status transition an Event reports.
+type Kind int
+
+const (
+	KindBehind    Kind = iota // clean → has unreleased commits
+	KindThreshold             // unreleased commits crossed the configured threshold
+	KindError                 // check started failing
+	KindDiverged              // the latest release has commits the branch doesn't
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindBehind:
+		return "behind"
+	case KindThreshold:
+		return "threshold"
+	case KindError:
+		return "error"
+	case KindDiverged:
+		return "diverged"
+	}
+	return "unknown"
+}
+
+// Event describes one repo transition between two consecutive checks.
+type Event struct {
+	Kind      Kind
+	Previous  github.RepoStatus
+	Current   github.RepoStatus
+	Threshold int
+}
+
+// Repo returns the "owner/repo" the event is about.
+func (e Event) Repo() string {
+	return e.Current.Owner + "/" + e.Current.Repo
+}
+
+// Title is a one-line summary suitable for a notification heading.
+func (e Event) Title() string {
+	switch e.Kind {
+	case KindBehind:
+		return fmt.Sprintf("%s needs deploy", e.Repo())
+	case KindThreshold:
+		return fmt.Sprintf("%s has %d+ unreleased commits", e.Repo(), e.Threshold)
+	case KindError:
+		return fmt.Sprintf("%s check failed", e.Repo())
+	case KindDiverged:
+		return fmt.Sprintf("%s release diverged from %s", e.Repo(), e.Current.Branch)
+	}
+	return e.Repo()
+}
+
+// Text is the notification body.
+func (e Event) Text() string {
+	c := e.Current
+	switch e.Kind {
+	case KindBehind, KindThreshold:
+		return fmt.Sprintf("%d commit(s) on %s since %s", c.CommitsAhead, c.Branch, c.TagName)
+	case KindError:
+		return c.ErrorMsg
+	case KindDiverged:
+		return c.Divergence() + "; merge it back before deploying"
+	}
+	return ""
+}
+
+// URL links to the most useful page for the event.
+func (e Event) URL() string {
+	c := e.Current
+	if e.Kind != KindError {
+		return c.CompareURL()
+	}
+	return c.URL()
+}
+
+// Diff compares two consecutive results for the same repo and returns the
+// transitions worth notifying about. A nil prev (first check) never fires,
+// so starting reprac doesn't replay the current state as news.
+func Diff(prev *github.RepoStatus, cur github.RepoStatus, threshold int) []Event {
+	if prev == nil || prev.Status == github.StatusLoading {
+		return nil
+	}
+
+	var events []Event
+	add := func(k Kind) {
+		events = append(events, Event{Kind: k, Previous: *prev, Current: cur, Threshold: threshold})
+	}
+
+	switch cur.Status {
+	case github.StatusBehind, github.StatusBroken:
+		if prev.Status == github.StatusClean {
+			add(KindBehind)
+		}
+		if threshold > 0 && prev.CommitsAhead < threshold && cur.CommitsAhead >= threshold {
+			add(KindThreshold)
+		}
+	case github.StatusDiverged:
+		if prev.Status != github.StatusDiverged {
+			add(KindDiverged)
+		}
+	case github.StatusError:
+		if prev.Status != github.StatusError {
+			add(KindError)
+		}
+	}
+	return events
+}
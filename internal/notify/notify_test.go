@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+func TestDiff(t *testing.T) {
+	status := func(s github.Status, ahead int) *github.RepoStatus {
+		return &github.RepoStatus{Owner: "org", Repo: "app", Status: s, CommitsAhead: ahead}
+	}
+	tests := []struct {
+		name      string
+		prev, cur *github.RepoStatus
+		threshold int
+		want      []Kind
+	}{
+		{"first check", nil, status(github.StatusBehind, 3), 0, nil},
+		{"clean to behind", status(github.StatusClean, 0), status(github.StatusBehind, 2), 0, []Kind{KindBehind}},
+		{"clean to broken", status(github.StatusClean, 0), status(github.StatusBroken, 2), 0, []Kind{KindBehind}},
+		{"crosses threshold", status(github.StatusBehind, 4), status(github.StatusBehind, 5), 5, []Kind{KindThreshold}},
+		{"already past threshold", status(github.StatusBehind, 6), status(github.StatusBehind, 7), 5, nil},
+		{"clean past threshold", status(github.StatusClean, 0), status(github.StatusBehind, 9), 5, []Kind{KindBehind, KindThreshold}},
+		{"diverged", status(github.StatusBehind, 2), status(github.StatusDiverged, 2), 0, []Kind{KindDiverged}},
+		{"still diverged", status(github.StatusDiverged, 2), status(github.StatusDiverged, 3), 0, nil},
+		{"error appears", status(github.StatusClean, 0), status(github.StatusError, 0), 0, []Kind{KindError}},
+		{"still failing", status(github.StatusError, 0), status(github.StatusError, 0), 0, nil},
+		{"unchanged behind", status(github.StatusBehind, 2), status(github.StatusBehind, 2), 0, nil},
+		{"unchanged clean", status(github.StatusClean, 0), status(github.StatusClean, 0), 0, nil},
+		{"after loading", status(github.StatusLoading, 0), status(github.StatusBehind, 2), 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var kinds []Kind
+			for _, e := range Diff(tt.prev, *tt.cur, tt.threshold) {
+				kinds = append(kinds, e.Kind)
+			}
+			if !slices.Equal(kinds, tt.want) {
+				t.Errorf("Diff = %v, want %v", kinds, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// Write renders analytics in the given format: "md", "html" or "json".
+func Write(w io.Writer, format string, rows []Analytics) error {
+	switch format {
+	case "md", "markdown":
+		return writeMarkdown(w, rows)
+	case "html":
+		return htmlTmpl.Execute(w, struct {
+			Generated time.Time
+			Rows      []Analytics
+		}{time.Now(), rows})
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+	return fmt.Errorf("unknown format %q (want md, html or json)", format)
+}
+
+func writeMarkdown(w io.Writer, rows []Analytics) error {
+	var sb strings.Builder
+	sb.WriteString("# Release cadence\n\n")
+	sb.WriteString(fmt.Sprintf("_Generated %s_\n\n", time.Now().Format("2006-01-02 15:04")))
+	sb.WriteString("| Repository | Releases | Per week | Mean interval | Mean lead time | Last release | Drift | Drift age |\n")
+	sb.WriteString("|---|---:|---:|---:|---:|---|---:|---:|\n")
+	for _, a := range rows {
+		if a.Error != "" && a.Releases == 0 {
+			sb.WriteString(fmt.Sprintf("| %s | ✗ %s | | | | | | |\n", a.Repo, a.Error))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %s | %d | %s |\n",
+			a.Repo, a.Releases, perWeek(a.PerWeek), Days(a.MeanInterval), Days(a.MeanLeadTime),
+			date(a.LastRelease), a.Drift, Days(a.DriftAge)))
+		// Figures from a partial failure are incomplete; say so under them.
+		if a.Error != "" {
+			sb.WriteString(fmt.Sprintf("| ↳ | ✗ incomplete: %s | | | | | | |\n", a.Error))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func perWeek(f float64) string {
+	if f == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.2f", f)
+}
+
+func date(t time.Time) string {
+	if t.IsZero() {
+		return "—"
+	}
+	return t.Local().Format("2006-01-02")
+}
+
+var htmlTmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"days":    Days,
+	"perWeek": perWeek,
+	"date":    date,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>reprac · release cadence</title>
+<style>
+  body { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; background: #1e1e2e; color: #cdd6f4; margin: 2rem; }
+  h1 { color: #cba6f7; font-size: 1.4rem; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { padding: .4rem .8rem; border-bottom: 1px solid #45475a; text-align: right; }
+  th { color: #89b4fa; }
+  td:first-child, th:first-child { text-align: left; color: #89b4fa; font-weight: bold; }
+  .err { color: #f38ba8; text-align: left; }
+  .muted { color: #6c7086; }
+</style>
+</head>
+<body>
+<h1>Release cadence</h1>
+<p class="muted">Generated {{.Generated.Format "2006-01-02 15:04"}}</p>
+<table>
+<tr><th>Repository</th><th>Releases</th><th>Per week</th><th>Mean interval</th><th>Mean lead time</th><th>Last release</th><th>Drift</th><th>Drift age</th></tr>
+{{range .Rows}}{{if and .Error (not .Releases)}}<tr><td>{{.Repo}}</td><td class="err" colspan="7">✗ {{.Error}}</td></tr>
+{{else}}<tr><td>{{.Repo}}</td><td>{{.Releases}}</td><td>{{perWeek .PerWeek}}</td><td>{{days .MeanInterval}}</td><td>{{days .MeanLeadTime}}</td><td>{{date .LastRelease}}</td><td>{{.Drift}}</td><td>{{days .DriftAge}}</td></tr>
+{{with .Error}}<tr><td class="muted">↳</td><td class="err" colspan="7">✗ incomplete: {{.}}</td></tr>
+{{end}}{{end}}{{end}}</table>
+</body>
+</html>
+`))
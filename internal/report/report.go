@@ -0,0 +1,108 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+// Analytics is the release cadence of one repo over its recent releases —
+// DORA-style deployment frequency and lead time, plus current drift.
+type Analytics struct {
+	Repo          string        `json:"repo"`
+	Releases      int           `json:"releases"`       // releases in the sampled window
+	Prereleases   int           `json:"prereleases"`    // pre-releases in the window, left out of the figures
+	FirstRelease  time.Time     `json:"first_release"`  // oldest release in the window
+	LastRelease   time.Time     `json:"last_release"`   // newest release
+	PerWeek       float64       `json:"per_week"`       // release frequency over the window
+	MeanInterval  time.Duration `json:"mean_interval"`  // mean time between releases
+	MeanLeadTime  time.Duration `json:"mean_lead_time"` // mean commit → release time
+	LeadSamples   int           `json:"lead_samples"`   // commits the lead time was averaged over
+	Drift         int           `json:"drift"`          // commits currently unreleased
+	DriftAge      time.Duration `json:"drift_age"`      // age of the oldest unreleased commit
+	Error         string        `json:"error,omitempty"`
+	GeneratedAt   time.Time     `json:"generated_at"`
+	ReleaseSample []string      `json:"release_sample"` // tag names considered, newest first
+}
+
+// Analyze computes cadence figures for a repo from its last n releases.
+// status supplies the current drift; pass the result of a fresh check.
+func Analyze(ctx context.Context, gh *github.Client, status github.RepoStatus, n int) Analytics {
+	now := time.Now()
+	a := Analytics{
+		Repo:        status.FullName(),
+		Drift:       status.CommitsAhead,
+		DriftAge:    status.UnreleasedAge(now),
+		GeneratedAt: now,
+	}
+	if status.Status == github.StatusError {
+		a.Error = status.ErrorMsg
+		return a
+	}
+
+	all, err := gh.ReleaseHistory(ctx, status.Owner, status.Repo, n)
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	// Release candidates would make a repo look like it ships more often.
+	var releases []github.Release
+	for _, r := range all {
+		if r.Prerelease {
+			a.Prereleases++
+			continue
+		}
+		releases = append(releases, r)
+	}
+	a.Releases = len(releases)
+	if len(releases) == 0 {
+		return a
+	}
+	for _, r := range releases {
+		a.ReleaseSample = append(a.ReleaseSample, r.TagName)
+	}
+
+	newest, oldest := releases[0], releases[len(releases)-1]
+	a.LastRelease = newest.PublishedAt
+	a.FirstRelease = oldest.PublishedAt
+
+	if len(releases) > 1 {
+		span := newest.PublishedAt.Sub(oldest.PublishedAt)
+		a.MeanInterval = span / time.Duration(len(releases)-1)
+		// Frequency over the window up to now, so a long quiet spell counts.
+		if window := now.Sub(oldest.PublishedAt); window > 0 {
+			a.PerWeek = float64(len(releases)-1) / (window.Hours() / (24 * 7))
+		}
+	}
+
+	// Lead time: for each release, how long its new commits waited.
+	var total time.Duration
+	for i := 0; i+1 < len(releases); i++ {
+		cur, prev := releases[i], releases[i+1]
+		dates, err := gh.CommitDates(ctx, status.Owner, status.Repo, prev.TagName, cur.TagName)
+		if err != nil {
+			a.Error = fmt.Sprintf("lead time %s...%s: %v", prev.TagName, cur.TagName, err)
+			break
+		}
+		for _, d := range dates {
+			if lead := cur.PublishedAt.Sub(d); lead > 0 {
+				total += lead
+				a.LeadSamples++
+			}
+		}
+	}
+	if a.LeadSamples > 0 {
+		a.MeanLeadTime = total / time.Duration(a.LeadSamples)
+	}
+	return a
+}
+
+// Days formats a duration as fractional days ("3.2d"), or "—" when zero.
+func Days(d time.Duration) string {
+	if d <= 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
@@ -0,0 +1,108 @@
+package risk
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+// Rule is a compiled config.RiskRule.
+type Rule struct {
+	Name    string
+	paths   []*regexp.Regexp
+	byName  []bool // pattern has no "/" and is matched against the file name
+	message *regexp.Regexp
+}
+
+// Compile prepares rules for Evaluate.
+func Compile(rules []config.RiskRule) ([]Rule, error) {
+	out := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		c := Rule{Name: r.Name}
+		for _, p := range r.Paths {
+			re, err := regexp.Compile(globToRegexp(p))
+			if err != nil {
+				return nil, fmt.Errorf("risk %s: invalid path %q: %w", r.Name, p, err)
+			}
+			c.paths = append(c.paths, re)
+			c.byName = append(c.byName, !strings.Contains(p, "/"))
+		}
+		if r.Message != "" {
+			re, err := regexp.Compile(r.Message)
+			if err != nil {
+				return nil, fmt.Errorf("risk %s: invalid message: %w", r.Name, err)
+			}
+			c.message = re
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// Evaluate returns the names of the rules matched by s's unreleased commits
+// and changed files, in rule order. Repos with nothing to deploy match none.
+func Evaluate(rules []Rule, s github.RepoStatus) []string {
+	if !s.NeedsDeploy() {
+		return nil
+	}
+	var out []string
+	for _, r := range rules {
+		if r.matches(s) {
+			out = append(out, r.Name)
+		}
+	}
+	return out
+}
+
+func (r Rule) matches(s github.RepoStatus) bool {
+	for _, f := range s.Diff.Files {
+		for i, re := range r.paths {
+			p := f.Path
+			if r.byName[i] {
+				p = path.Base(p)
+			}
+			if re.MatchString(p) {
+				return true
+			}
+		}
+	}
+	if r.message != nil {
+		for _, c := range s.Commits {
+			if r.message.MatchString(c.Message + "\n" + c.Body) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// globToRegexp translates a path glob: "**/" matches any number of
+// directories, "**" anything, "*" and "?" stay within one path segment.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**/") {
+				sb.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(glob[i:], "**") {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+// handleMetrics writes the latest results in the Prometheus text exposition format.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	entries, updated := s.snapshot()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	m := &metricWriter{w: w}
+
+	m.family("reprac_commits_ahead", "Commits on the default branch since the latest tag or release.")
+	for _, e := range entries {
+		if st := e.Status; st != nil && hasRef(st) {
+			m.sample("reprac_commits_ahead", repoLabels(st), float64(st.CommitsAhead))
+		}
+	}
+
+	m.family("reprac_status", "Current repo status; 1 for the active status label, 0 otherwise.")
+	for _, e := range entries {
+		st := e.Status
+		if st == nil {
+			continue
+		}
+		for _, s := range []github.Status{github.StatusClean, github.StatusBehind, github.StatusNoRelease, github.StatusError, github.StatusBroken, github.StatusDiverged} {
+			v := 0.0
+			if st.Status == s {
+				v = 1
+			}
+			m.sample("reprac_status", repoLabels(st)+`,status="`+s.String()+`"`, v)
+		}
+	}
+
+	m.family("reprac_last_release_timestamp", "Unix time the latest tag or release was cut.")
+	for _, e := range entries {
+		if st := e.Status; st != nil && !st.ReleasedAt.IsZero() {
+			m.sample("reprac_last_release_timestamp", repoLabels(st), unix(st.ReleasedAt))
+		}
+	}
+
+	m.family("reprac_oldest_unreleased_commit_timestamp", "Unix author time of the oldest unreleased commit; absent when nothing is unreleased.")
+	for _, e := range entries {
+		if st := e.Status; st != nil && st.CommitsAhead > 0 && !st.Oldest.IsZero() {
+			m.sample("reprac_oldest_unreleased_commit_timestamp", repoLabels(st), unix(st.Oldest))
+		}
+	}
+
+	m.family("reprac_check_duration_seconds", "Wall time of the latest check.")
+	for _, e := range entries {
+		if st := e.Status; st != nil && st.Duration > 0 {
+			m.sample("reprac_check_duration_seconds", repoLabels(st), st.Duration.Seconds())
+		}
+	}
+
+	m.family("reprac_last_check_timestamp", "Unix time of the latest check.")
+	for _, e := range entries {
+		if st := e.Status; st != nil && !st.LastChecked.IsZero() {
+			m.sample("reprac_last_check_timestamp", repoLabels(st), unix(st.LastChecked))
+		}
+	}
+
+	if !updated.IsZero() {
+		m.family("reprac_last_run_timestamp", "Unix time the latest full check run finished.")
+		m.sample("reprac_last_run_timestamp", "", unix(updated))
+	}
+
+	if s.gh != nil {
+		if rl := s.gh.RateLimit(); rl.Limit > 0 {
+			m.family("reprac_github_rate_limit_remaining", "GitHub API requests left in the current window.")
+			m.sample("reprac_github_rate_limit_remaining", "", float64(rl.Remaining))
+			m.family("reprac_github_rate_limit_limit", "GitHub API requests allowed per window.")
+			m.sample("reprac_github_rate_limit_limit", "", float64(rl.Limit))
+			m.family("reprac_github_rate_limit_reset_timestamp", "Unix time the GitHub API window resets.")
+			m.sample("reprac_github_rate_limit_reset_timestamp", "", unix(rl.Reset))
+		}
+	}
+}
+
+// hasRef reports whether commits-ahead is meaningful for the result.
+func hasRef(st *github.RepoStatus) bool {
+	return st.NeedsDeploy() || st.Status == github.StatusClean
+}
+
+func repoLabels(st *github.RepoStatus) string {
+	return fmt.Sprintf(`owner="%s",repo="%s",branch="%s"`, escapeLabel(st.Owner), escapeLabel(st.Repo), escapeLabel(st.Branch))
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func unix(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+type metricWriter struct {
+	w io.Writer
+}
+
+func (m *metricWriter) family(name, help string) {
+	fmt.Fprintf(m.w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+func (m *metricWriter) sample(name, labels string, v float64) {
+	if labels != "" {
+		fmt.Fprintf(m.w, "%s{%s} %s\n", name, labels, strconv.FormatFloat(v, 'f', -1, 64))
+		return
+	}
+	fmt.Fprintf(m.w, "%s %s\n", name, strconv.FormatFloat(v, 'f', -1, 64))
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+var indexTmpl = template.Must(template.New("index").Funcs(template.FuncMap{
+	"badge": badge,
+	"age": func(s *github.RepoStatus) string {
+		d := s.UnreleasedAge(time.Now())
+		if d <= 0 {
+			return "—"
+		}
+		if d < 48*time.Hour {
+			return fmt.Sprintf("%dh", int(d.Hours()))
+		}
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	},
+	"clock": func(t time.Time) string {
+		if t.IsZero() {
+			return "—"
+		}
+		return t.Local().Format("15:04:05")
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="60">
+<title>reprac</title>
+<style>
+  body { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; background: #1e1e2e; color: #cdd6f4; margin: 2rem; }
+  h1 { color: #cba6f7; font-size: 1.4rem; margin-bottom: .2rem; }
+  table { border-collapse: collapse; width: 100%; margin-top: 1.5rem; }
+  th, td { padding: .45rem .8rem; border-bottom: 1px solid #45475a; text-align: left; white-space: nowrap; }
+  th { color: #89b4fa; }
+  a { color: #89b4fa; text-decoration: none; }
+  .muted { color: #6c7086; }
+  .notes { color: #6c7086; font-style: italic; }
+  .branch { color: #89dceb; }
+  .tag { color: #cba6f7; }
+  .ahead { color: #f9e2af; font-weight: bold; }
+  .badge { padding: .1rem .5rem; border-radius: .3rem; }
+  .behind { background: #f9e2af; color: #1e1e2e; font-weight: bold; }
+  .clean { color: #a6e3a1; }
+  .no_release { color: #89dceb; }
+  .error { color: #f38ba8; }
+  .broken { background: #f38ba8; color: #1e1e2e; font-weight: bold; }
+  .diverged { background: #cba6f7; color: #1e1e2e; font-weight: bold; }
+  .risk { color: #fab387; font-weight: bold; }
+  .ci-passing { color: #a6e3a1; }
+  .ci-failing { color: #f38ba8; }
+  .ci-pending { color: #f9e2af; }
+  .loading { color: #6c7086; }
+</style>
+</head>
+<body>
+<h1>reprac</h1>
+<div class="muted">track unreleased changes · {{if .Updated.IsZero}}first check running…{{else}}updated {{clock .Updated}}{{end}}</div>
+<table>
+<tr><th>Status</th><th>Repository</th><th>Branch</th><th>Last tag / release</th><th>Unreleased</th><th>Oldest</th><th>CI</th><th>Notes</th><th>Checked</th></tr>
+{{range .Entries}}{{$s := .Status}}<tr>
+{{if $s}}<td>{{badge $s}}</td>{{else}}<td><span class="loading">⏳ loading</span></td>{{end}}
+<td><a href="https://github.com/{{.Config.Owner}}/{{.Config.Repo}}">{{.Config.Owner}}/{{.Config.Repo}}</a></td>
+{{if $s}}<td class="branch">{{$s.Branch}}</td>
+<td class="tag">{{with $s.TagName}}{{.}}{{else}}<span class="muted">—</span>{{end}}</td>
+<td>{{if $s.NeedsDeploy}}<a class="ahead" href="{{$s.CompareURL}}">+{{$s.CommitsAhead}} commit(s)</a>{{range $s.Risks}} <span class="risk">⚠ {{.}}</span>{{end}}{{else if eq $s.Status.String "error"}}<span class="error">{{$s.ErrorMsg}}</span>{{else}}<span class="muted">—</span>{{end}}{{with $s.Divergence}}<div class="muted">⑂ {{.}}</div>{{end}}</td>
+<td>{{age $s}}</td>
+<td>{{with $s.CI}}<span class="ci-{{.}}">{{.}}</span>{{else}}<span class="muted">—</span>{{end}}</td>{{else}}<td></td><td></td><td></td><td></td><td></td>{{end}}
+<td class="notes">{{.Config.Notes}}</td>
+<td class="muted">{{if $s}}{{clock $s.LastChecked}}{{end}}</td>
+</tr>
+{{end}}</table>
+<p class="muted">JSON: <a href="/api/repos">/api/repos</a></p>
+</body>
+</html>
+`))
+
+func badge(s *github.RepoStatus) template.HTML {
+	var text string
+	switch s.Status {
+	case github.StatusBehind:
+		text = "▲ need deploy"
+	case github.StatusBroken:
+		text = "▲ need deploy · CI failing"
+	case github.StatusDiverged:
+		text = "⑂ diverged"
+		if s.CI == github.CIFailing {
+			text += " · CI failing"
+		}
+	case github.StatusClean:
+		text = "✓ up to date"
+	case github.StatusNoRelease:
+		text = "◈ no release"
+	case github.StatusError:
+		text = "✗ error"
+	default:
+		text = "⏳ loading"
+	}
+	return template.HTML(fmt.Sprintf(`<span class="badge %s">%s</span>`, s.Status, template.HTMLEscapeString(text)))
+}
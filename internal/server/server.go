@@ -0,0 +1,127 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+// Entry pairs a configured repo with its latest result.
+type Entry struct {
+	Config config.RepoConfig
+	Status *github.RepoStatus // nil until the first check completes
+}
+
+// Server serves a read-only dashboard and JSON API over the latest results.
+// Checks run elsewhere and are pushed in with Update.
+type Server struct {
+	gh        *github.Client // for rate-limit metrics; may be nil
+	mu        sync.RWMutex
+	entries   []Entry
+	updatedAt time.Time
+}
+
+func New(gh *github.Client) *Server {
+	return &Server{gh: gh}
+}
+
+// Update replaces the snapshot. results must be in the same order as cfg.Repos.
+func (s *Server) Update(cfg *config.Config, results []github.RepoStatus) {
+	entries := make([]Entry, len(cfg.Repos))
+	for i, r := range cfg.Repos {
+		entries[i] = Entry{Config: r}
+		if i < len(results) {
+			res := results[i]
+			entries[i].Status = &res
+		}
+	}
+	s.mu.Lock()
+	s.entries = entries
+	s.updatedAt = time.Now()
+	s.mu.Unlock()
+}
+
+// Seed shows last-known results (e.g. from the state file) before the first check.
+func (s *Server) Seed(cfg *config.Config, known map[string]github.RepoStatus) {
+	entries := make([]Entry, len(cfg.Repos))
+	for i, r := range cfg.Repos {
+		entries[i] = Entry{Config: r}
+		if res, ok := known[r.Owner+"/"+r.Repo]; ok {
+			entries[i].Status = &res
+		}
+	}
+	s.mu.Lock()
+	s.entries = entries
+	s.mu.Unlock()
+}
+
+func (s *Server) snapshot() ([]Entry, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.entries, s.updatedAt
+}
+
+// Handler returns the HTTP routes:
+//
+//	GET /                          HTML dashboard
+//	GET /api/repos                 JSON array of RepoStatus, in brief form
+//	GET /api/repos/{owner}/{repo}  JSON RepoStatus for one repo, with every commit
+//	GET /metrics                   Prometheus metrics
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /api/repos", s.handleRepos)
+	mux.HandleFunc("GET /api/repos/{owner}/{repo}", s.handleRepo)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return mux
+}
+
+func (s *Server) handleRepos(w http.ResponseWriter, r *http.Request) {
+	entries, _ := s.snapshot()
+	out := make([]github.RepoStatus, 0, len(entries))
+	for _, e := range entries {
+		if e.Status != nil {
+			out = append(out, e.Status.Brief())
+		} else {
+			out = append(out, github.RepoStatus{Owner: e.Config.Owner, Repo: e.Config.Repo, Status: github.StatusLoading})
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleRepo(w http.ResponseWriter, r *http.Request) {
+	owner, repo := r.PathValue("owner"), r.PathValue("repo")
+	entries, _ := s.snapshot()
+	for _, e := range entries {
+		if e.Config.Owner == owner && e.Config.Repo == repo {
+			if e.Status == nil {
+				writeJSON(w, http.StatusOK, github.RepoStatus{Owner: owner, Repo: repo, Status: github.StatusLoading})
+				return
+			}
+			writeJSON(w, http.StatusOK, e.Status)
+			return
+		}
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "repo not tracked"})
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	entries, updated := s.snapshot()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = indexTmpl.Execute(w, struct {
+		Entries []Entry
+		Updated time.Time
+	}{entries, updated})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
@@ -0,0 +1,53 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+// Resolve lists the repos matched by every entry in cfg.Sources, deduplicated,
+// with Origin set. A failing source doesn't stop the others; its error is
+// joined into the returned one.
+func Resolve(ctx context.Context, gh *github.Client, cfg *config.Config) ([]config.RepoConfig, error) {
+	seen := make(map[string]bool)
+	var out []config.RepoConfig
+	var errs []error
+	for _, src := range cfg.Sources {
+		found, err := gh.ListRepos(ctx, github.RepoFilter{
+			Org:             src.Org,
+			Team:            src.Team,
+			Query:           src.Query,
+			Topic:           src.Topic,
+			ExcludeArchived: src.ExcludeArchived,
+			Match:           src.Match,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source %s: %w", src, err))
+			continue
+		}
+		for _, r := range found {
+			key := r.Owner + "/" + r.Name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, config.RepoConfig{Owner: r.Owner, Repo: r.Name, Origin: "source: " + src.String()})
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// Expand resolves cfg.Sources and merges the result into cfg.Repos.
+// Explicit entries win over source entries for the same repo.
+func Expand(ctx context.Context, gh *github.Client, cfg *config.Config) error {
+	if len(cfg.Sources) == 0 {
+		return nil
+	}
+	repos, err := Resolve(ctx, gh, cfg)
+	cfg.Merge(repos)
+	return err
+}
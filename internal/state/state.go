@@ -0,0 +1,86 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+)
+
+// State is the last-known status of every checked repo, shared between
+// `reprac daemon` and the TUI.
+type State struct {
+	UpdatedAt time.Time                    `json:"updated_at"`
+	Repos     map[string]github.RepoStatus `json:"repos"` // keyed by "owner/repo"
+}
+
+// DefaultPath returns the default state file path (~/.config/reprac/state.json).
+func DefaultPath() string {
+	return filepath.Join(config.Dir(), "state.json")
+}
+
+// Load reads a state file. A missing file yields an empty state.
+func Load(path string) (*State, error) {
+	st := &State{Repos: make(map[string]github.RepoStatus)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return st, nil
+		}
+		return nil, fmt.Errorf("reading state: %w", err)
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("parsing state: %w", err)
+	}
+	if st.Repos == nil {
+		st.Repos = make(map[string]github.RepoStatus)
+	}
+	return st, nil
+}
+
+// Put records a result, keyed by its full name. Only the brief form is kept
+// (see RepoStatus.Brief), so the file stays small.
+func (s *State) Put(r github.RepoStatus) {
+	s.Repos[r.FullName()] = r.Brief()
+	if r.LastChecked.After(s.UpdatedAt) {
+		s.UpdatedAt = r.LastChecked
+	}
+}
+
+// Save writes the state atomically (temp file, fsync, rename) so a reader
+// never sees a half-written file while the daemon is updating it, and a crash
+// leaves either the old or the new state.
+func Save(path string, s *State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating state dir: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".state-*.json")
+	if err != nil {
+		return fmt.Errorf("writing state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing state: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing state: %w", err)
+	}
+	return config.SyncDir(filepath.Dir(path))
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cursorKey returns the key of the repo under the cursor, or "".
+func (m Model) cursorKey() string {
+	rows := m.rows()
+	if m.cursor >= len(rows) {
+		return ""
+	}
+	r := rows[m.cursor]
+	return repoKey(r.Owner, r.Repo)
+}
+
+// inlineCommits returns how many commit lines the row for key shows.
+func (m Model) inlineCommits(key string) int {
+	res := m.results[key]
+	if !m.expanded[key] || res == nil || !res.NeedsDeploy() {
+		return 0
+	}
+	return min(len(res.Commits), github.InlineCommits)
+}
+
+// commitLine returns the selected commit line of the cursor row (1-based),
+// or 0 when the cursor is on the repo row itself.
+func (m Model) commitLine() int {
+	if m.commitRow > m.inlineCommits(m.cursorKey()) {
+		return 0 // the row collapsed or its result changed underneath
+	}
+	return m.commitRow
+}
+
+// selectedCommit returns the commit under the cursor, if it's on one.
+func (m Model) selectedCommit() (*github.RepoStatus, github.CommitInfo, bool) {
+	n := m.commitLine()
+	if n == 0 {
+		return nil, github.CommitInfo{}, false
+	}
+	res := m.results[m.cursorKey()]
+	return res, res.Commits[n-1], true
+}
+
+// moveCursor moves one line down (dir 1) or up (-1), stepping through the
+// commit lines of expanded rows on the way.
+func (m Model) moveCursor(dir int) Model {
+	n := m.commitLine()
+	switch {
+	case dir > 0 && n < m.inlineCommits(m.cursorKey()):
+		m.commitRow = n + 1
+	case dir > 0 && m.cursor < len(m.cfg.Repos)-1:
+		m.cursor++
+		m.commitRow = 0
+	case dir < 0 && n > 0:
+		m.commitRow = n - 1
+	case dir < 0 && m.cursor > 0:
+		m.cursor--
+		m.commitRow = m.inlineCommits(m.cursorKey())
+	}
+	return m
+}
+
+// showCommit opens the detail view on the selected commit, for its full message.
+func (m Model) showCommit() (tea.Model, tea.Cmd) {
+	return m.openDetail(m.cursorKey(), components.TabCommits, m.commitLine()-1)
+}
@@ -0,0 +1,62 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adhaniscuber/reprac/internal/report"
+	"github.com/adhaniscuber/reprac/internal/ui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderAnalytics draws the release cadence overlay for one repo.
+// a is nil while the figures are still being fetched.
+func RenderAnalytics(repo string, a *report.Analytics, width, height int) string {
+	var sb strings.Builder
+	sb.WriteString(styles.ModalTitle.Render("📈  Release cadence · " + repo))
+	sb.WriteString("\n\n")
+
+	label := styles.ModalLabel.Width(18)
+	row := func(name, value string) {
+		sb.WriteString(label.Render(name) + value + "\n")
+	}
+
+	switch {
+	case a == nil:
+		sb.WriteString(styles.Faint.Render("⏳ fetching releases...") + "\n")
+	case a.Error != "" && a.Releases == 0:
+		sb.WriteString(styles.BadgeError.Render("✗ "+a.Error) + "\n")
+	case a.Releases == 0:
+		sb.WriteString(styles.BadgeNoRelease.Render("◈ no releases or tags yet") + "\n")
+	default:
+		releases := fmt.Sprintf("%d (since %s)", a.Releases, a.FirstRelease.Local().Format("02 Jan 2006"))
+		if a.Prereleases > 0 {
+			releases += styles.Faint.Render(fmt.Sprintf("  %d pre-release(s) not counted", a.Prereleases))
+		}
+		row("releases", releases)
+		if a.PerWeek > 0 {
+			row("frequency", fmt.Sprintf("%.2f / week", a.PerWeek))
+		}
+		row("mean interval", report.Days(a.MeanInterval))
+		row("mean lead time", fmt.Sprintf("%s  %s", report.Days(a.MeanLeadTime),
+			styles.Faint.Render(fmt.Sprintf("over %d commits", a.LeadSamples))))
+		row("last release", a.LastRelease.Local().Format("02 Jan 2006"))
+		sb.WriteString("\n")
+		drift := styles.BadgeClean.Render("0")
+		if a.Drift > 0 {
+			drift = styles.CommitsAhead.Render(fmt.Sprintf("+%d commit(s)", a.Drift)) +
+				styles.Faint.Render(" · oldest "+report.Days(a.DriftAge))
+		}
+		row("drift", drift)
+		if a.Error != "" {
+			sb.WriteString("\n" + styles.BadgeError.Render(truncate(a.Error, 60)) + "\n")
+		}
+	}
+
+	sb.WriteString("\n" + styles.KeyHint("esc", "close"))
+
+	dialog := styles.Modal.Render(sb.String())
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog,
+		lipgloss.WithWhitespaceForeground(styles.ColorMuted),
+	)
+}
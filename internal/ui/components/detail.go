@@ -0,0 +1,316 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Detail view tabs, in display order.
+const (
+	TabCommits = iota
+	TabPRs
+	TabFiles
+	TabReleases
+	TabCI
+)
+
+// DetailTabs are the tab titles of the detail view.
+var DetailTabs = []string{"Commits", "Pull requests", "Files", "Releases", "CI"}
+
+// DetailData is what the detail view fetches on open, beyond the repo's
+// last RepoStatus. Loaded is false while the requests are in flight.
+type DetailData struct {
+	Loaded   bool
+	PRs      []github.PullRequest
+	Releases []github.Release
+	Checks   []github.Check
+	// per-tab fetch errors
+	PRErr, ReleaseErr, CheckErr string
+}
+
+// detailBodyLines caps how many lines of a commit body or release notes are shown.
+const detailBodyLines = 8
+
+// DetailItems returns how many selectable items the tab lists.
+func DetailItems(s *github.RepoStatus, d *DetailData, tab int) int {
+	switch tab {
+	case TabCommits:
+		if s != nil {
+			return len(s.Commits)
+		}
+	case TabPRs:
+		if d != nil {
+			return len(d.PRs)
+		}
+	case TabFiles:
+		if s != nil {
+			return len(s.Diff.Files)
+		}
+	case TabReleases:
+		if d != nil {
+			return len(d.Releases)
+		}
+	case TabCI:
+		if d != nil {
+			return len(d.Checks)
+		}
+	}
+	return 0
+}
+
+// DetailURL returns the GitHub link of the selected item in tab, or "" when
+// nothing is selected.
+func DetailURL(s *github.RepoStatus, d *DetailData, tab, cursor int) string {
+	if s == nil || cursor < 0 || cursor >= DetailItems(s, d, tab) {
+		return ""
+	}
+	switch tab {
+	case TabCommits:
+		return s.CommitURL(s.Commits[cursor].SHA)
+	case TabPRs:
+		return d.PRs[cursor].URL
+	case TabFiles:
+		return s.FileDiffURL(s.Diff.Files[cursor].Path)
+	case TabReleases:
+		if r := d.Releases[cursor]; r.URL != "" {
+			return r.URL
+		}
+		return s.URL() + "/releases/tag/" + d.Releases[cursor].TagName
+	case TabCI:
+		if c := d.Checks[cursor]; c.URL != "" {
+			return c.URL
+		}
+		if s.HeadSHA == "" {
+			return s.URL() + "/commits/" + s.Branch
+		}
+		return s.CommitURL(s.HeadSHA)
+	}
+	return ""
+}
+
+// RenderDetail draws the full-screen detail view for one repo, with hints
+// for the given bindings in the footer.
+func RenderDetail(s *github.RepoStatus, d *DetailData, tab, cursor, width, height int, bindings []key.Binding) string {
+	inner := width - 2
+
+	// Title: repo · branch · tag
+	title := styles.RepoName.Render(s.FullName())
+	if s.Branch != "" {
+		title += styles.Faint.Render(" · ") + styles.BranchName.Render(s.Branch)
+	}
+	if s.TagName != "" {
+		title += styles.Faint.Render(" · ") + styles.TagName.Render(s.TagName)
+	}
+	if s.CommitsAhead > 0 {
+		title += styles.Faint.Render(" · ") + styles.CommitsAhead.Render(fmt.Sprintf("+%d unreleased", s.CommitsAhead))
+	}
+	if note := s.Divergence(); note != "" {
+		title += styles.Faint.Render(" · ") + styles.BadgeDiverged.Render("⑂ "+note)
+	}
+
+	tabs := make([]string, len(DetailTabs))
+	for i, t := range DetailTabs {
+		label := fmt.Sprintf("%d %s", i+1, t)
+		if n := DetailItems(s, d, i); n > 0 {
+			label += fmt.Sprintf(" (%d)", n)
+		}
+		if i == tab {
+			tabs[i] = styles.TabActive.Render(label)
+		} else {
+			tabs[i] = styles.TabInactive.Render(label)
+		}
+	}
+	tabBar := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+
+	bodyHeight := height - 2 /* panel border */ - 3 /* title, tabs, gap */ - 1 /* footer */
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+	body := detailBody(s, d, tab, cursor, inner)
+	lines := scrollLines(body, cursor, bodyHeight, inner)
+
+	content := " " + title + "\n" + tabBar + "\n\n" + strings.Join(lines, "\n")
+	panel := RenderTitledPanel("detail", content, width, height-3, styles.ColorSubtle)
+
+	hints := []string{styles.KeyHint("tab/1-5", "switch tab")}
+	for _, b := range bindings {
+		hints = append(hints, styles.KeyHint(b.Help().Key, b.Help().Desc))
+	}
+	hints = append(hints, styles.KeyHint("esc", "close"))
+	return panel + "\n" + styles.Footer.Width(width).Render(strings.Join(hints, ""))
+}
+
+// detailItem is one selectable entry in a tab: its lines, first line on top.
+type detailItem []string
+
+func detailBody(s *github.RepoStatus, d *DetailData, tab, cursor, width int) []detailItem {
+	if tab != TabCommits && tab != TabFiles && (d == nil || !d.Loaded) {
+		return []detailItem{{styles.Faint.Render("  ⏳ fetching...")}}
+	}
+
+	var items []detailItem
+	var empty, note string
+	switch tab {
+	case TabCommits:
+		empty = "  ✓ nothing unreleased"
+		for _, c := range s.Commits {
+			head := fmt.Sprintf("  %s  %s  %s  %s",
+				styles.Timestamp.Render(c.Date.Local().Format("02 Jan 15:04")),
+				styles.BranchName.Render(c.SHA),
+				styles.TagName.Render(truncate(joinPeople(c.Authors()), 24)),
+				truncate(c.Message, width-58))
+			items = append(items, append(detailItem{head}, indentBody(c.Body, width)...))
+		}
+		if len(s.Commits) < min(s.CommitsAhead, github.MaxCommits) {
+			note = fmt.Sprintf("  ⏳ fetching %d older commits...", min(s.CommitsAhead, github.MaxCommits)-len(s.Commits))
+		} else if s.CommitsAhead > len(s.Commits) {
+			note = fmt.Sprintf("  + %d older commits not returned by the API", s.CommitsAhead-len(s.Commits))
+		}
+
+	case TabPRs:
+		empty, note = "  no pull requests since the last release", d.PRErr
+		for _, p := range d.PRs {
+			state := styles.BadgeClean.Render("merged")
+			if p.State == "open" {
+				state = styles.CommitsAhead.Render("open  ")
+				if p.Draft {
+					state = styles.Faint.Render("draft ")
+				}
+			}
+			items = append(items, detailItem{fmt.Sprintf("  %s  %s  %s  %s  %s",
+				styles.BranchName.Render(fmt.Sprintf("#%-5d", p.Number)),
+				state,
+				styles.Timestamp.Render(p.Date.Local().Format("02 Jan 06")),
+				styles.TagName.Render(truncate("@"+p.Author, 16)),
+				truncate(p.Title, width-50))})
+		}
+
+	case TabFiles:
+		empty = "  no changed files"
+		for _, f := range s.Diff.Files {
+			items = append(items, detailItem{fmt.Sprintf("  %s %s  %s  %s",
+				styles.BadgeClean.Render(fmt.Sprintf("%6s", "+"+FormatCount(f.Additions))),
+				styles.BadgeError.Render(fmt.Sprintf("%-6s", "-"+FormatCount(f.Deletions))),
+				styles.Faint.Render(fmt.Sprintf("%-8s", f.Status)),
+				truncate(f.Path, width-30))})
+		}
+		if len(s.Diff.TopDirs) > 0 {
+			dirs := make([]string, len(s.Diff.TopDirs))
+			for i, d := range s.Diff.TopDirs {
+				dirs[i] = fmt.Sprintf("%s (%s)", d.Dir, FormatCount(d.Changes))
+			}
+			note = "  most changed: " + strings.Join(dirs, ", ")
+		}
+		if len(s.Diff.Files) < s.Diff.FileCount {
+			note = "  ⏳ fetching the changed files..."
+		} else if s.Diff.Truncated {
+			note += "  · the API lists only the first 300 files, so totals are a lower bound"
+		}
+
+	case TabReleases:
+		empty, note = "  ◈ no releases or tags yet", d.ReleaseErr
+		for _, r := range d.Releases {
+			head := fmt.Sprintf("  %s  %s  %s",
+				styles.TagName.Render(r.TagName),
+				styles.Timestamp.Render(r.PublishedAt.Local().Format("02 Jan 2006")),
+				styles.Faint.Render(ago(r.PublishedAt)))
+			if r.Name != "" && r.Name != r.TagName {
+				head += "  " + r.Name
+			}
+			if r.Prerelease {
+				head += "  " + styles.AgeWarning.Render("pre-release")
+			}
+			items = append(items, append(detailItem{head}, indentBody(r.Body, width)...))
+		}
+
+	case TabCI:
+		empty, note = "  no checks reported for "+s.Branch, d.CheckErr
+		for _, c := range d.Checks {
+			var icon string
+			switch c.State {
+			case "success":
+				icon = styles.BadgeClean.Render("✓")
+			case "failure":
+				icon = styles.BadgeError.Render("✗")
+			case "pending":
+				icon = styles.CommitsAhead.Render("●")
+			default:
+				icon = styles.Faint.Render("–")
+			}
+			items = append(items, detailItem{fmt.Sprintf("  %s  %s  %s", icon, c.Name, styles.Faint.Render(c.State))})
+		}
+	}
+
+	for i := range items {
+		if i == cursor {
+			for j, l := range items[i] {
+				items[i][j] = styles.RowSelected.Width(width).Render(l)
+			}
+		}
+	}
+	if len(items) == 0 && note == "" {
+		items = append(items, detailItem{styles.Faint.Render(empty)})
+	}
+	if note != "" {
+		if tab == TabCommits || tab == TabFiles {
+			items = append(items, detailItem{"", styles.Faint.Render(note)})
+		} else {
+			items = append(items, detailItem{"", styles.BadgeError.Render("  ✗ " + note)})
+		}
+	}
+	return items
+}
+
+// indentBody renders a commit body or release notes under its item.
+func indentBody(body string, width int) []string {
+	body = strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	if body == "" {
+		return nil
+	}
+	var out []string
+	for i, l := range strings.Split(body, "\n") {
+		if i == detailBodyLines {
+			out = append(out, styles.Faint.Render("        …"))
+			break
+		}
+		out = append(out, styles.Notes.Render("        "+truncate(l, width-10)))
+	}
+	return out
+}
+
+// scrollLines flattens items into exactly height lines, scrolled so the
+// selected item is visible.
+func scrollLines(items []detailItem, cursor, height, width int) []string {
+	var lines []string
+	top := 0
+	for i, it := range items {
+		if i == cursor {
+			top = len(lines)
+		}
+		lines = append(lines, it...)
+	}
+	start := 0
+	if top >= height {
+		start = top - height/3
+	}
+	end := min(start+height, len(lines))
+	out := append([]string(nil), lines[start:end]...)
+	for len(out) < height {
+		out = append(out, strings.Repeat(" ", width))
+	}
+	return out
+}
+
+// ago renders how long ago t was: "3h ago", "12d ago".
+func ago(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return FormatAge(time.Since(t)) + " ago"
+}
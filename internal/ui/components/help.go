@@ -0,0 +1,32 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/adhaniscuber/reprac/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderHelp draws the ? overlay listing every binding in km.
+func RenderHelp(km help.KeyMap, width, height int) string {
+	h := help.New()
+	h.ShowAll = true
+	h.FullSeparator = "    "
+	h.Styles.FullKey = styles.HelpKey
+	h.Styles.FullDesc = styles.HelpDesc
+	h.Styles.FullSeparator = styles.Faint
+
+	var sb strings.Builder
+	sb.WriteString(styles.ModalTitle.Render("⌨  Keyboard shortcuts"))
+	sb.WriteString("\n\n")
+	sb.WriteString(h.View(km))
+	sb.WriteString("\n\n")
+	sb.WriteString(styles.Faint.Render("remap under keys: in the config · mouse: click, double-click, wheel, click a header to sort"))
+	sb.WriteString("\n\n" + styles.KeyHint("any key", "close"))
+
+	dialog := styles.Modal.Render(sb.String())
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, dialog,
+		lipgloss.WithWhitespaceForeground(styles.ColorMuted),
+	)
+}
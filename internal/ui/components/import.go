@@ -0,0 +1,149 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ImportModal is a Bubble Tea model for the "import from org" overlay.
+type ImportModal struct {
+	inputs          []textinput.Model
+	excludeArchived bool
+	focused         int // len(inputs) = the archived toggle
+	width           int
+	height          int
+}
+
+const (
+	importOrg = iota
+	importTeam
+	importTopic
+	importMatch
+)
+
+type ImportSubmitMsg struct{ Filter github.RepoFilter }
+
+func NewImportModal(width, height int) ImportModal {
+	placeholders := []string{"e.g. your-org", "e.g. payments (optional)", "e.g. service (optional)", "e.g. ^svc- (optional)"}
+
+	inputs := make([]textinput.Model, len(placeholders))
+	for i := range inputs {
+		t := textinput.New()
+		t.Placeholder = placeholders[i]
+		t.CharLimit = 100
+		t.Prompt = "  "
+		if i == 0 {
+			t.Focus()
+		}
+		inputs[i] = t
+	}
+
+	return ImportModal{
+		inputs:          inputs,
+		excludeArchived: true,
+		width:           width,
+		height:          height,
+	}
+}
+
+func (m ImportModal) focus(i int) ImportModal {
+	if m.focused < len(m.inputs) {
+		m.inputs[m.focused].Blur()
+	}
+	m.focused = i
+	if m.focused < len(m.inputs) {
+		m.inputs[m.focused].Focus()
+	}
+	return m
+}
+
+func (m ImportModal) Update(msg tea.Msg) (ImportModal, tea.Cmd) {
+	fields := len(m.inputs) + 1
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return ModalCancelMsg{} }
+
+		case "tab", "down":
+			return m.focus((m.focused + 1) % fields), nil
+
+		case "shift+tab", "up":
+			return m.focus((m.focused - 1 + fields) % fields), nil
+
+		case " ":
+			if m.focused == len(m.inputs) {
+				m.excludeArchived = !m.excludeArchived
+				return m, nil
+			}
+
+		case "enter":
+			f := github.RepoFilter{
+				Org:             strings.TrimSpace(m.inputs[importOrg].Value()),
+				Team:            strings.TrimSpace(m.inputs[importTeam].Value()),
+				Topic:           strings.TrimSpace(m.inputs[importTopic].Value()),
+				Match:           strings.TrimSpace(m.inputs[importMatch].Value()),
+				ExcludeArchived: m.excludeArchived,
+			}
+			if f.Org == "" {
+				return m.focus(importOrg), nil
+			}
+			return m, func() tea.Msg { return ImportSubmitMsg{Filter: f} }
+		}
+	}
+
+	var cmds []tea.Cmd
+	for i := range m.inputs {
+		var cmd tea.Cmd
+		m.inputs[i], cmd = m.inputs[i].Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m ImportModal) View() string {
+	labels := []string{"Organization", "Team", "Topic", "Name matches (regexp)"}
+
+	var sb strings.Builder
+	sb.WriteString(styles.ModalTitle.Render("⇣  Import Repositories"))
+	sb.WriteString("\n\n")
+
+	for i, inp := range m.inputs {
+		sb.WriteString(styles.ModalLabel.Render(labels[i]) + "\n")
+		if m.focused == i {
+			sb.WriteString(styles.InputFocused.Width(38).Render(inp.View()) + "\n\n")
+		} else {
+			sb.WriteString(styles.InputStyle.Width(38).Render(inp.View()) + "\n\n")
+		}
+	}
+
+	box := "[ ]"
+	if m.excludeArchived {
+		box = "[x]"
+	}
+	toggle := box + " exclude archived"
+	if m.focused == len(m.inputs) {
+		toggle = styles.TagName.Render(toggle)
+	} else {
+		toggle = styles.ModalLabel.Render(toggle)
+	}
+	sb.WriteString(toggle + "\n\n")
+
+	hints := lipgloss.JoinHorizontal(lipgloss.Top,
+		styles.KeyHint("enter", "import"),
+		styles.KeyHint("tab", "next"),
+		styles.KeyHint("space", "toggle"),
+		styles.KeyHint("esc", "cancel"),
+	)
+	sb.WriteString(hints)
+
+	dialog := styles.Modal.Render(sb.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, dialog,
+		lipgloss.WithWhitespaceForeground(styles.ColorMuted),
+	)
+}
@@ -3,38 +3,44 @@ package components
 import (
 	"strings"
 
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/ui/styles"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/adhaniscuber/reprac/internal/ui/styles"
 )
 
 // AddRepoResult is returned when the modal is submitted.
 type AddRepoResult struct {
-	Owner string
-	Repo  string
-	Notes string
+	Owner            string
+	Repo             string
+	Notes            string
+	MaxUnreleasedAge config.AgeThresholds
 }
 
-// AddRepoModal is a Bubble Tea model for the "add repo" overlay.
+// AddRepoModal is a Bubble Tea model for the "add repo" overlay. It also
+// serves as the edit dialog when created with NewEditRepoModal.
 type AddRepoModal struct {
 	inputs  []textinput.Model
 	focused int
+	editing bool
+	err     string
 	width   int
 	height  int
 }
 
 const (
-	fieldOwner = 0
-	fieldRepo  = 1
-	fieldNotes = 2
+	fieldOwner = iota
+	fieldRepo
+	fieldNotes
+	fieldAgeWarning
+	fieldAgeCritical
 )
 
 func NewAddRepoModal(width, height int) AddRepoModal {
-	labels := []string{"owner", "repo", "notes (optional)"}
-	placeholders := []string{"e.g. your-org", "e.g. your-app", "e.g. Production API"}
+	placeholders := []string{"e.g. your-org", "e.g. your-app", "e.g. Production API", "e.g. 7d (optional)", "e.g. 2w (optional)"}
 
-	inputs := make([]textinput.Model, 3)
+	inputs := make([]textinput.Model, len(placeholders))
 	for i := range inputs {
 		t := textinput.New()
 		t.Placeholder = placeholders[i]
@@ -43,7 +49,6 @@ func NewAddRepoModal(width, height int) AddRepoModal {
 		if i == 0 {
 			t.Focus()
 		}
-		_ = labels[i]
 		inputs[i] = t
 	}
 
@@ -55,6 +60,25 @@ func NewAddRepoModal(width, height int) AddRepoModal {
 	}
 }
 
+// NewEditRepoModal returns the modal pre-filled with r.
+func NewEditRepoModal(r config.RepoConfig, width, height int) AddRepoModal {
+	m := NewAddRepoModal(width, height)
+	m.editing = true
+	m.inputs[fieldOwner].SetValue(r.Owner)
+	m.inputs[fieldRepo].SetValue(r.Repo)
+	m.inputs[fieldNotes].SetValue(r.Notes)
+	m.inputs[fieldAgeWarning].SetValue(r.MaxUnreleasedAge.Warning.String())
+	m.inputs[fieldAgeCritical].SetValue(r.MaxUnreleasedAge.Critical.String())
+	return m
+}
+
+func (m AddRepoModal) focus(i int) AddRepoModal {
+	m.inputs[m.focused].Blur()
+	m.focused = i
+	m.inputs[m.focused].Focus()
+	return m
+}
+
 type ModalSubmitMsg struct{ Result AddRepoResult }
 type ModalCancelMsg struct{}
 
@@ -66,35 +90,40 @@ func (m AddRepoModal) Update(msg tea.Msg) (AddRepoModal, tea.Cmd) {
 			return m, func() tea.Msg { return ModalCancelMsg{} }
 
 		case "tab", "down":
-			m.inputs[m.focused].Blur()
-			m.focused = (m.focused + 1) % len(m.inputs)
-			m.inputs[m.focused].Focus()
+			return m.focus((m.focused + 1) % len(m.inputs)), nil
 
 		case "shift+tab", "up":
-			m.inputs[m.focused].Blur()
-			m.focused = (m.focused - 1 + len(m.inputs)) % len(m.inputs)
-			m.inputs[m.focused].Focus()
+			return m.focus((m.focused - 1 + len(m.inputs)) % len(m.inputs)), nil
 
 		case "enter":
 			owner := strings.TrimSpace(m.inputs[fieldOwner].Value())
 			repo := strings.TrimSpace(m.inputs[fieldRepo].Value())
 			notes := strings.TrimSpace(m.inputs[fieldNotes].Value())
-			if owner != "" && repo != "" {
-				return m, func() tea.Msg {
-					return ModalSubmitMsg{Result: AddRepoResult{
-						Owner: owner, Repo: repo, Notes: notes,
-					}}
-				}
-			}
 			// Highlight empty required fields
 			if owner == "" {
-				m.inputs[m.focused].Blur()
-				m.focused = fieldOwner
-				m.inputs[m.focused].Focus()
-			} else if repo == "" {
-				m.inputs[m.focused].Blur()
-				m.focused = fieldRepo
-				m.inputs[m.focused].Focus()
+				return m.focus(fieldOwner), nil
+			}
+			if repo == "" {
+				return m.focus(fieldRepo), nil
+			}
+			var ages config.AgeThresholds
+			var err error
+			if ages.Warning, err = config.ParseAge(m.inputs[fieldAgeWarning].Value()); err != nil {
+				m.err = err.Error()
+				return m.focus(fieldAgeWarning), nil
+			}
+			if ages.Critical, err = config.ParseAge(m.inputs[fieldAgeCritical].Value()); err != nil {
+				m.err = err.Error()
+				return m.focus(fieldAgeCritical), nil
+			}
+			if ages.Warning > 0 && ages.Critical > 0 && ages.Warning > ages.Critical {
+				m.err = "warning age is later than critical"
+				return m.focus(fieldAgeWarning), nil
+			}
+			return m, func() tea.Msg {
+				return ModalSubmitMsg{Result: AddRepoResult{
+					Owner: owner, Repo: repo, Notes: notes, MaxUnreleasedAge: ages,
+				}}
 			}
 		}
 	}
@@ -109,10 +138,14 @@ func (m AddRepoModal) Update(msg tea.Msg) (AddRepoModal, tea.Cmd) {
 }
 
 func (m AddRepoModal) View() string {
-	labels := []string{"Owner", "Repo", "Notes (optional)"}
+	labels := []string{"Owner", "Repo", "Notes (optional)", "Warn when unreleased for", "Critical when unreleased for"}
 
 	var sb strings.Builder
-	sb.WriteString(styles.ModalTitle.Render("➕  Add Repository"))
+	if m.editing {
+		sb.WriteString(styles.ModalTitle.Render("✎  Edit Repository"))
+	} else {
+		sb.WriteString(styles.ModalTitle.Render("➕  Add Repository"))
+	}
 	sb.WriteString("\n\n")
 
 	for i, inp := range m.inputs {
@@ -128,6 +161,10 @@ func (m AddRepoModal) View() string {
 		sb.WriteString(inputBox + "\n\n")
 	}
 
+	if m.err != "" {
+		sb.WriteString(styles.BadgeError.Render(m.err) + "\n\n")
+	}
+
 	enterHint := styles.KeyHint("enter", "confirm")
 	tabHint := styles.KeyHint("tab", "next")
 	escHint := styles.KeyHint("esc", "cancel")
@@ -5,8 +5,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/adhaniscuber/reprac/internal/config"
 	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/history"
 	"github.com/adhaniscuber/reprac/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -14,16 +17,56 @@ import (
 type Column struct {
 	Title string
 	Width int
+	Sort  int // direction of the first click-to-sort: 1 ascending, -1 descending, 0 not sortable
 }
 
 var Columns = []Column{
-	{Title: "STATUS", Width: 18},
-	{Title: "REPOSITORY", Width: 30},
-	{Title: "BRANCH", Width: 12},
-	{Title: "LAST TAG / RELEASE", Width: 22},
-	{Title: "UNRELEASED", Width: 14},
-	{Title: "NOTES", Width: 24},
-	{Title: "CHECKED", Width: 10},
+	{Title: "STATUS", Width: 18, Sort: -1},
+	{Title: "REPOSITORY", Width: 30, Sort: 1},
+	{Title: "BRANCH", Width: 12, Sort: 1},
+	{Title: "LAST TAG / RELEASE", Width: 22, Sort: -1},
+	{Title: "UNRELEASED", Width: 14, Sort: -1},
+	{Title: "OLDEST", Width: 10, Sort: -1},
+	{Title: "CHANGES", Width: 24, Sort: -1},
+	{Title: "RISKS", Width: 22, Sort: -1},
+	{Title: "TREND", Width: 16},
+	{Title: "CI", Width: 10, Sort: -1},
+	{Title: "AUTHORS", Width: 20, Sort: -1},
+	{Title: "NOTES", Width: 24, Sort: 1},
+	{Title: "CHECKED", Width: 10, Sort: -1},
+}
+
+// Column indices into Columns, in display order.
+const (
+	colStatus = iota
+	colRepo
+	colBranch
+	colTag
+	colUnreleased
+	colOldest
+	colChanges
+	colRisks
+	colTrend
+	colCI
+	colAuthors
+	colNotes
+	colChecked
+)
+
+// fitColumns returns how many leading columns fit in width.
+// Trailing columns are dropped rather than wrapping the row.
+func fitColumns(width int) int {
+	used := 0
+	for i, col := range Columns {
+		used += col.Width
+		if used > width {
+			if i == 0 {
+				return 1
+			}
+			return i
+		}
+	}
+	return len(Columns)
 }
 
 // TableRow represents one rendered row.
@@ -32,12 +75,34 @@ type TableRow struct {
 	Cells   []string
 }
 
-func RenderHeader(width int) string {
-	cells := make([]string, len(Columns))
-	for i, col := range Columns {
+// ColumnAt returns the index of the column at x (0 = first cell), or -1.
+func ColumnAt(x, width int) int {
+	for i, col := range Columns[:fitColumns(width)] {
+		if x < col.Width {
+			return i
+		}
+		x -= col.Width
+	}
+	return -1
+}
+
+// RenderHeader draws the column titles, marking column sortCol with the
+// sort direction (sortDir 0 when the table keeps config order).
+func RenderHeader(width, sortCol, sortDir int) string {
+	cells := make([]string, fitColumns(width))
+	for i := range cells {
+		col := Columns[i]
+		title := col.Title
+		if sortDir != 0 && i == sortCol {
+			arrow := " ▲"
+			if sortDir < 0 {
+				arrow = " ▼"
+			}
+			title = truncate(title, col.Width-2-len([]rune(arrow))) + arrow
+		}
 		cells[i] = styles.TableHeader.
 			Width(col.Width).
-			Render(truncate(col.Title, col.Width-2))
+			Render(truncate(title, col.Width-2))
 	}
 	row := lipgloss.JoinHorizontal(lipgloss.Top, cells...)
 	return styles.TableHeader.Width(width).Render(row)
@@ -46,24 +111,26 @@ func RenderHeader(width int) string {
 func RenderRow(
 	idx int,
 	selected bool,
+	commitCursor int, // 1-based inline commit under the cursor; 0 for none
 	repoKey string,
-	owner, repo, notes string,
+	r config.RepoConfig,
 	status *github.RepoStatus,
+	trend []int,
 	loading bool,
 	expanded bool,
 	termWidth int,
 ) string {
-	cells := makeRowCells(owner, repo, notes, status, loading)
+	cells := makeRowCells(r, status, trend, loading)
 
-	rendered := make([]string, len(cells))
-	for i, cell := range cells {
-		rendered[i] = styles.Cell.Width(Columns[i].Width).Render(cell)
+	rendered := make([]string, fitColumns(termWidth))
+	for i := range rendered {
+		rendered[i] = styles.Cell.Width(Columns[i].Width).Render(cells[i])
 	}
 
 	row := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
 
 	var rowStyle lipgloss.Style
-	if selected {
+	if selected && commitCursor == 0 {
 		rowStyle = styles.RowSelected
 	} else if idx%2 == 0 {
 		rowStyle = styles.RowNormal
@@ -74,7 +141,7 @@ func RenderRow(
 	header := rowStyle.Width(termWidth).Render(row)
 
 	// If not expanded or no commit data, return just the header
-	if !expanded || status == nil || status.Status != github.StatusBehind || len(status.Commits) == 0 {
+	if !expanded || status == nil || !status.NeedsDeploy() || len(status.Commits) == 0 {
 		return header
 	}
 
@@ -83,16 +150,18 @@ func RenderRow(
 		indent  = 4  // leading spaces
 		dateW   = 18 // "15:04:05 02-Jan-06"
 		shaW    = 7  // short SHA
-		colGap  = 3  // gap between columns
+		authorW = 16
+		colGap  = 3 // gap between columns
 	)
 
 	shaStyle  := lipgloss.NewStyle().Foreground(lipgloss.Color("#5eacd3")).Width(shaW)
 	dateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#777777")).Width(dateW)
+	authorStyle := styles.TagName.Copy().Width(authorW)
 	msgStyle  := lipgloss.NewStyle().Foreground(lipgloss.Color("#aaaaaa"))
 	moreStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")).Italic(true)
 	indentStyle := rowStyle.Copy().Bold(false)
 
-	fixedPrefix := indent + dateW + colGap + shaW + colGap
+	fixedPrefix := indent + dateW + colGap + shaW + colGap + authorW + colGap
 	maxMsgLen := termWidth - fixedPrefix - 2
 	if maxMsgLen < 10 {
 		maxMsgLen = 10
@@ -101,26 +170,33 @@ func RenderRow(
 	pad := strings.Repeat(" ", indent)
 	gap := strings.Repeat(" ", colGap)
 
+	shown := status.Commits[:min(len(status.Commits), github.InlineCommits)]
 	lines := []string{header}
-	for _, c := range status.Commits {
+	for i, c := range shown {
 		dateStr := ""
 		if !c.Date.IsZero() {
 			dateStr = c.Date.Local().Format("15:04:05 02-Jan-06")
 		}
-		line := indentStyle.Width(termWidth).Render(
-			pad +
+		lineStyle, lead := indentStyle, pad
+		if i+1 == commitCursor {
+			lineStyle, lead = styles.RowSelected.Copy().Bold(false), "  ▸ "
+		}
+		line := lineStyle.Width(termWidth).Render(
+			lead +
 				dateStyle.Render(dateStr) +
 				gap +
 				shaStyle.Render(c.SHA) +
 				gap +
+				authorStyle.Render(truncate(joinPeople(c.Authors()), authorW)) +
+				gap +
 				msgStyle.Render(truncate(c.Message, maxMsgLen)),
 		)
 		lines = append(lines, line)
 	}
 
 	// "+N more commits" if needed
-	if status.CommitsAhead > len(status.Commits) {
-		more := status.CommitsAhead - len(status.Commits)
+	if status.CommitsAhead > len(shown) {
+		more := status.CommitsAhead - len(shown)
 		moreLine := indentStyle.Width(termWidth).Render(
 			pad + moreStyle.Render(fmt.Sprintf("+ %d more commits...", more)),
 		)
@@ -130,24 +206,43 @@ func RenderRow(
 	return strings.Join(lines, "\n")
 }
 
-func makeRowCells(owner, repo, notes string, s *github.RepoStatus, loading bool) []string {
-	if loading || s == nil {
+func makeRowCells(r config.RepoConfig, s *github.RepoStatus, trend []int, loading bool) []string {
+	// With a last-known result we keep showing it while the refresh runs.
+	if s == nil {
 		return []string{
 			styles.BadgeLoading.Render("⏳ loading..."),
-			styles.RepoName.Render(truncate(owner+"/"+repo, Columns[1].Width-2)),
+			renderRepoName(r),
+			styles.Faint.Render("—"),
+			styles.Faint.Render("—"),
+			styles.Faint.Render("—"),
+			styles.Faint.Render("—"),
+			styles.Faint.Render("—"),
 			styles.Faint.Render("—"),
+			renderTrend(trend),
 			styles.Faint.Render("—"),
 			styles.Faint.Render("—"),
-			styles.Notes.Render(truncate(notes, Columns[5].Width-2)),
+			renderNotes(r),
 			styles.Faint.Render("—"),
 		}
 	}
 
+	now := time.Now()
+	age := s.UnreleasedAge(now)
+	level := r.MaxUnreleasedAge.Level(age)
+
 	// Status cell
 	var statusCell string
 	switch s.Status {
 	case github.StatusBehind:
-		statusCell = styles.BadgeDeploy.Render("▲ need deploy")
+		statusCell = deployBadge(level).Render("▲ need deploy")
+	case github.StatusBroken:
+		// The deploy marker keeps its age colour; the CI failure sits beside it.
+		statusCell = deployBadge(level).Render("▲") + styles.BadgeBroken.Render("✗ CI red")
+	case github.StatusDiverged:
+		statusCell = styles.BadgeDiverged.Render("⑂ diverged")
+		if s.CI == github.CIFailing {
+			statusCell += styles.BadgeBroken.Render("✗ CI")
+		}
 	case github.StatusClean:
 		statusCell = styles.BadgeClean.Render("✓ up to date")
 	case github.StatusNoRelease:
@@ -159,16 +254,16 @@ func makeRowCells(owner, repo, notes string, s *github.RepoStatus, loading bool)
 	}
 
 	// Repo cell
-	repoCell := styles.RepoName.Render(truncate(owner+"/"+repo, Columns[1].Width-2))
+	repoCell := renderRepoName(r)
 
 	// Branch
 	branch := s.Branch
 	if branch == "" {
 		branch = "main"
 	}
-	branchCell := styles.BranchName.Render(truncate(branch, Columns[2].Width-2))
+	branchCell := styles.BranchName.Render(truncate(branch, Columns[colBranch].Width-2))
 
-	// Tag/Release
+	// Tag/Release, followed by days since it was cut
 	var tagCell string
 	if s.TagName == "" {
 		tagCell = styles.Faint.Render("—")
@@ -179,34 +274,232 @@ func makeRowCells(owner, repo, notes string, s *github.RepoStatus, loading bool)
 		} else {
 			prefix = "⬢ "
 		}
-		tagCell = styles.TagName.Render(truncate(prefix+s.TagName, Columns[3].Width-2))
+		suffix := ""
+		if days := s.DaysSinceRelease(now); days >= 0 {
+			suffix = fmt.Sprintf(" %dd", days)
+		}
+		tagW := Columns[colTag].Width - 2 - len(suffix)
+		tagCell = styles.TagName.Render(truncate(prefix+s.TagName, tagW)) + styles.Timestamp.Render(suffix)
 	}
 
 	// Commits ahead
 	var commitsCell string
 	switch s.Status {
-	case github.StatusBehind:
+	case github.StatusBehind, github.StatusBroken:
 		commitsCell = styles.CommitsAhead.Render(fmt.Sprintf("+%d commit(s)", s.CommitsAhead))
+	case github.StatusDiverged:
+		// ahead on the branch / only in the release
+		commitsCell = styles.CommitsAhead.Render(fmt.Sprintf("+%d", s.CommitsAhead)) +
+			styles.BadgeError.Render(fmt.Sprintf(" −%d on tag", s.BehindBy))
 	case github.StatusClean:
 		commitsCell = styles.BadgeClean.Render("0")
 	case github.StatusError:
-		commitsCell = styles.BadgeError.Render(truncate(s.ErrorMsg, Columns[4].Width-2))
+		commitsCell = styles.BadgeError.Render(truncate(s.ErrorMsg, Columns[colUnreleased].Width-2))
 	default:
 		commitsCell = styles.Faint.Render("—")
 	}
 
+	// Age of the oldest unreleased commit
+	var oldestCell string
+	if age > 0 {
+		text := FormatAge(age)
+		switch level {
+		case config.AgeCritical:
+			oldestCell = styles.BadgeError.Bold(true).Render(text)
+		case config.AgeWarning:
+			oldestCell = styles.AgeWarning.Render(text)
+		default:
+			oldestCell = styles.Timestamp.Render(text)
+		}
+	} else {
+		oldestCell = styles.Faint.Render("—")
+	}
+
 	// Notes
-	notesCell := styles.Notes.Render(truncate(notes, Columns[5].Width-2))
+	notesCell := renderNotes(r)
 
 	// Last checked
 	var checkedCell string
-	if s.LastChecked.IsZero() {
+	if loading {
+		checkedCell = styles.BadgeLoading.Render("⏳")
+	} else if s.LastChecked.IsZero() {
 		checkedCell = styles.Faint.Render("—")
 	} else {
 		checkedCell = styles.Timestamp.Render(s.LastChecked.Local().Format("15:04:05"))
 	}
 
-	return []string{statusCell, repoCell, branchCell, tagCell, commitsCell, notesCell, checkedCell}
+	return []string{statusCell, repoCell, branchCell, tagCell, commitsCell, oldestCell, renderChanges(s), renderRisks(s.Risks), renderTrend(trend), renderCI(s.CI), renderAuthors(s.Authors()), notesCell, checkedCell}
+}
+
+// SortKey returns what column col sorts r by: a number, or text for the
+// name-like columns. Unchecked repos sort as -1.
+func SortKey(col int, r config.RepoConfig, s *github.RepoStatus, now time.Time) (float64, string) {
+	switch col {
+	case colRepo:
+		return 0, strings.ToLower(r.Owner + "/" + r.Repo)
+	case colNotes:
+		return 0, strings.ToLower(r.Notes)
+	}
+	if s == nil {
+		return -1, ""
+	}
+	switch col {
+	case colStatus:
+		// most urgent first when descending
+		rank := map[github.Status]float64{
+			github.StatusClean: 0, github.StatusNoRelease: 1, github.StatusError: 2,
+			github.StatusBehind: 3, github.StatusDiverged: 4, github.StatusBroken: 5,
+		}
+		if v, ok := rank[s.Status]; ok {
+			return v, ""
+		}
+		return -1, ""
+	case colBranch:
+		return 0, strings.ToLower(s.Branch)
+	case colTag:
+		return float64(s.DaysSinceRelease(now)), ""
+	case colUnreleased:
+		return float64(s.CommitsAhead), ""
+	case colOldest:
+		return s.UnreleasedAge(now).Seconds(), ""
+	case colChanges:
+		return float64(s.Diff.Additions + s.Diff.Deletions), ""
+	case colRisks:
+		return float64(len(s.Risks)), ""
+	case colCI:
+		return map[string]float64{github.CIPassing: 1, github.CIPending: 2, github.CIFailing: 3}[s.CI], ""
+	case colAuthors:
+		return float64(len(s.Authors())), ""
+	case colChecked:
+		return float64(s.LastChecked.Unix()), ""
+	}
+	return 0, ""
+}
+
+// renderRepoName shows owner/repo, marking pinned repos.
+func renderRepoName(r config.RepoConfig) string {
+	if r.Pinned {
+		return styles.Pinned.Render("★ ") + styles.RepoName.Render(truncate(r.Owner+"/"+r.Repo, Columns[colRepo].Width-4))
+	}
+	return styles.RepoName.Render(truncate(r.Owner+"/"+r.Repo, Columns[colRepo].Width-2))
+}
+
+// renderNotes shows the repo's notes, or where it came from if it has none.
+func renderNotes(r config.RepoConfig) string {
+	if r.Notes == "" && r.Origin != "" {
+		return styles.Faint.Render(truncate(r.Origin, Columns[colNotes].Width-2))
+	}
+	return styles.Notes.Render(truncate(r.Notes, Columns[colNotes].Width-2))
+}
+
+// renderChanges summarizes the unreleased diff: "+1.2k/-300 in 42 files".
+// A truncated file list makes every figure a lower bound: "≥+1.2k/-300 in
+// 300+ files".
+func renderChanges(s *github.RepoStatus) string {
+	d := s.Diff
+	if !s.NeedsDeploy() || d.FileCount == 0 {
+		return styles.Faint.Render("—")
+	}
+	files, atLeast := fmt.Sprint(d.FileCount), ""
+	if d.Truncated {
+		files += "+"
+		atLeast = styles.Faint.Render("≥")
+	}
+	return atLeast + styles.BadgeClean.Render("+"+FormatCount(d.Additions)) +
+		styles.Faint.Render("/") +
+		styles.BadgeError.Render("-"+FormatCount(d.Deletions)) +
+		styles.Faint.Render(" in "+files+" files")
+}
+
+// renderRisks shows a badge per matched risk rule: "⚠ migration ⚠ infra".
+func renderRisks(risks []string) string {
+	if len(risks) == 0 {
+		return styles.Faint.Render("—")
+	}
+	badges := make([]string, len(risks))
+	for i, r := range risks {
+		badges[i] = "⚠ " + r
+	}
+	return styles.Risk.Render(truncate(strings.Join(badges, " "), Columns[colRisks].Width-2))
+}
+
+// FormatCount renders a line count compactly: "300", "1.2k", "12k".
+func FormatCount(n int) string {
+	switch {
+	case n < 1000:
+		return fmt.Sprint(n)
+	case n < 10000:
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	default:
+		return fmt.Sprintf("%dk", n/1000)
+	}
+}
+
+// renderCI shows the CI state of the branch head.
+func renderCI(ci string) string {
+	switch ci {
+	case github.CIPassing:
+		return styles.BadgeClean.Render("✓ passing")
+	case github.CIFailing:
+		return styles.BadgeError.Render("✗ failing")
+	case github.CIPending:
+		return styles.CommitsAhead.Render("● pending")
+	}
+	return styles.Faint.Render("—")
+}
+
+// renderAuthors lists who wrote the unreleased commits, most active first.
+func renderAuthors(people []github.Person) string {
+	if len(people) == 0 {
+		return styles.Faint.Render("—")
+	}
+	w := Columns[colAuthors].Width - 2
+	text, shown := people[0].String(), 1
+	for _, p := range people[1:] {
+		next := text + ", " + p.String()
+		suffix := ""
+		if rest := len(people) - shown - 1; rest > 0 {
+			suffix = fmt.Sprintf(" +%d", rest)
+		}
+		if len([]rune(next))+len(suffix) > w {
+			break
+		}
+		text, shown = next, shown+1
+	}
+	if rest := len(people) - shown; rest > 0 {
+		more := fmt.Sprintf(" +%d", rest)
+		return styles.TagName.Render(truncate(text, w-len(more))) + styles.Faint.Render(more)
+	}
+	return styles.TagName.Render(truncate(text, w))
+}
+
+// joinPeople renders "@alice, @bob".
+func joinPeople(people []github.Person) string {
+	names := make([]string, len(people))
+	for i, p := range people {
+		names[i] = p.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// renderTrend draws commits-ahead history as a sparkline.
+func renderTrend(trend []int) string {
+	if len(trend) == 0 {
+		return styles.Faint.Render("—")
+	}
+	return styles.Sparkline.Render(history.Sparkline(trend))
+}
+
+// FormatAge renders a duration compactly: "45m", "6h", "12d".
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
 }
 
 func TableWidth() int {
@@ -217,6 +510,17 @@ func TableWidth() int {
 	return total
 }
 
+// deployBadge is the need-deploy badge style for an unreleased-age level.
+func deployBadge(level config.AgeLevel) lipgloss.Style {
+	switch level {
+	case config.AgeCritical:
+		return styles.BadgeDeployCritical
+	case config.AgeWarning:
+		return styles.BadgeDeployWarning
+	}
+	return styles.BadgeDeploy
+}
+
 func truncate(s string, maxLen int) string {
 	if maxLen <= 0 {
 		return ""
@@ -258,7 +562,9 @@ func RenderSummary(total, pending, loading int, width int) string {
 
 // ── Footer ────────────────────────────────────────────────────────────────────
 
-func RenderFooter(width int, showModal bool) string {
+// RenderFooter draws key hints for bindings (or the modal's keys), dropping
+// trailing hints that don't fit.
+func RenderFooter(width int, showModal bool, bindings []key.Binding) string {
 	var hints []string
 	if showModal {
 		hints = []string{
@@ -267,18 +573,17 @@ func RenderFooter(width int, showModal bool) string {
 			styles.KeyHint("esc", "cancel"),
 		}
 	} else {
-		hints = []string{
-			styles.KeyHint("enter", "expand"),
-			styles.KeyHint("E/C", "expand/collapse all"),
-			styles.KeyHint("r", "refresh all"),
-			styles.KeyHint("a", "add"),
-			styles.KeyHint("d", "delete"),
-			styles.KeyHint("o", "browser"),
-			styles.KeyHint("q", "quit"),
+		for _, b := range bindings {
+			if b.Enabled() {
+				hints = append(hints, styles.KeyHint(b.Help().Key, b.Help().Desc))
+			}
 		}
 	}
-	footer := strings.Join(hints, "")
 	ts := styles.Timestamp.Render(time.Now().Format("15:04"))
+	for len(hints) > 1 && lipgloss.Width(strings.Join(hints, ""))+lipgloss.Width(ts)+4 > width {
+		hints = hints[:len(hints)-1]
+	}
+	footer := strings.Join(hints, "")
 	spacer := lipgloss.NewStyle().Width(width - lipgloss.Width(footer) - lipgloss.Width(ts) - 4).Render("")
 	return styles.Footer.Width(width).Render(footer + spacer + ts)
 }
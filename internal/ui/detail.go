@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/ui/components"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// detailReleases is how many releases the detail view lists.
+const detailReleases = 10
+
+func (m Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	items := components.DetailItems(m.results[m.detailKey], m.detail, m.detailTab)
+	tabs := len(components.DetailTabs)
+
+	switch s := msg.String(); {
+	case s == "esc" || key.Matches(msg, m.keys.Quit, m.keys.Detail):
+		m.showDetail = false
+		m.detail = nil
+
+	case key.Matches(msg, m.keys.Up):
+		if m.detailCursor > 0 {
+			m.detailCursor--
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if m.detailCursor < items-1 {
+			m.detailCursor++
+		}
+
+	case key.Matches(msg, m.keys.Top):
+		m.detailCursor = 0
+
+	case key.Matches(msg, m.keys.Bottom):
+		m.detailCursor = max(items-1, 0)
+
+	case key.Matches(msg, m.keys.Open, m.keys.Copy):
+		url := components.DetailURL(m.results[m.detailKey], m.detail, m.detailTab, m.detailCursor)
+		if url == "" {
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Open) {
+			return m.openURL(url)
+		}
+		return m.copyText(url)
+
+	case key.Matches(msg, m.keys.Refresh):
+		m.detail = nil
+		return m, m.loadDetail(*m.results[m.detailKey])
+
+	// Tab switching comes after the keymap, so remapped keys win.
+	case s == "tab" || s == "right" || s == "l":
+		m.detailTab = (m.detailTab + 1) % tabs
+		m.detailCursor = 0
+
+	case s == "shift+tab" || s == "left" || s == "h":
+		m.detailTab = (m.detailTab - 1 + tabs) % tabs
+		m.detailCursor = 0
+
+	case len(s) == 1 && s[0] >= '1' && int(s[0]-'1') < tabs:
+		m.detailTab = int(s[0] - '1')
+		m.detailCursor = 0
+	}
+	return m, nil
+}
+
+// openDetail shows the detail view for key on tab, with item cursor selected.
+func (m Model) openDetail(key string, tab, cursor int) (tea.Model, tea.Cmd) {
+	res := m.results[key]
+	if res == nil {
+		m.statusMsg = fmt.Sprintf("%s hasn't been checked yet", key)
+		return m, nil
+	}
+	m.showDetail = true
+	m.detailKey = key
+	m.detailTab = tab
+	m.detailCursor = cursor
+	m.detail = nil
+	cmds := []tea.Cmd{m.loadDetail(*res)}
+	// Cached results only carry the first few commits; check again for the rest.
+	if res.Partial() && !m.loading[key] {
+		idx := slices.IndexFunc(m.cfg.Repos, func(r config.RepoConfig) bool { return repoKey(r.Owner, r.Repo) == key })
+		if idx >= 0 {
+			cmds = append(cmds, m.checkRepo(m.cfg.Repos[idx]))
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// loadDetail fetches PRs, releases and CI checks for the detail view in parallel.
+func (m Model) loadDetail(s github.RepoStatus) tea.Cmd {
+	gh, key := m.gh, m.detailKey
+	return func() tea.Msg {
+		ctx := context.Background()
+		d := components.DetailData{Loaded: true}
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			prs, err := gh.PullRequests(ctx, s.Owner, s.Repo, s.Branch, s.ReleasedAt)
+			d.PRs = prs
+			if err != nil {
+				d.PRErr = err.Error()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			rels, err := gh.ReleaseHistory(ctx, s.Owner, s.Repo, detailReleases)
+			d.Releases = rels
+			if err != nil {
+				d.ReleaseErr = err.Error()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			checks, err := gh.ListChecks(ctx, s.Owner, s.Repo, s.Branch)
+			d.Checks = checks
+			if err != nil {
+				d.CheckErr = err.Error()
+			}
+		}()
+		wg.Wait()
+		return detailMsg{key: key, data: d}
+	}
+}
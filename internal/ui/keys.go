@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds the dashboard's key bindings. It implements help.KeyMap, so
+// the footer and the ? overlay always show the keys actually bound.
+type keyMap struct {
+	Up, Down, Top, Bottom             key.Binding
+	Expand, ExpandAll, CollapseAll    key.Binding
+	RefreshAll, Refresh               key.Binding
+	Add, Edit, Delete, Import         key.Binding
+	MoveUp, MoveDown, Pin, Undo, Redo key.Binding
+	Detail, Analytics                 key.Binding
+	Open, OpenCompare, OpenRelease    key.Binding
+	Copy, Help, Quit                  key.Binding
+}
+
+// defaultKeyMap builds the bindings from config.KeyActions.
+func defaultKeyMap() keyMap {
+	var k keyMap
+	actions := k.actions()
+	for _, a := range config.KeyActions {
+		if b, ok := actions[a.Name]; ok {
+			*b = key.NewBinding(key.WithKeys(a.Keys...), key.WithHelp(keyHelp(a.Keys), a.Help))
+		}
+	}
+	return k
+}
+
+// newKeyMap returns the default bindings with the config's keys: applied.
+// Unknown actions are skipped; config validation reports them.
+func newKeyMap(overrides config.KeyBindings) keyMap {
+	k := defaultKeyMap()
+	actions := k.actions()
+	for name, keys := range overrides {
+		b, ok := actions[name]
+		if !ok || len(keys) == 0 {
+			continue
+		}
+		b.SetKeys(keys...)
+		b.SetHelp(keyHelp(keys), b.Help().Desc)
+	}
+	return k
+}
+
+// arrowNames shortens arrow key names in help text.
+var arrowNames = strings.NewReplacer("up", "↑", "down", "↓", "left", "←", "right", "→")
+
+// keyHelp names keys for the help views: "↑/k", "space".
+func keyHelp(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = arrowNames.Replace(k)
+		if k == " " {
+			names[i] = "space"
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// actions maps each config.KeyActions name to its binding.
+func (k *keyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up": &k.Up, "down": &k.Down, "top": &k.Top, "bottom": &k.Bottom,
+		"expand": &k.Expand, "expand_all": &k.ExpandAll, "collapse_all": &k.CollapseAll,
+		"refresh_all": &k.RefreshAll, "refresh": &k.Refresh,
+		"add": &k.Add, "edit": &k.Edit, "delete": &k.Delete, "import": &k.Import,
+		"move_up": &k.MoveUp, "move_down": &k.MoveDown, "pin": &k.Pin,
+		"undo": &k.Undo, "redo": &k.Redo, "detail": &k.Detail, "analytics": &k.Analytics,
+		"open": &k.Open, "open_compare": &k.OpenCompare, "open_release": &k.OpenRelease,
+		"copy": &k.Copy, "help": &k.Help, "quit": &k.Quit,
+	}
+}
+
+// ShortHelp is shown in the footer.
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Expand, k.RefreshAll, k.Add, k.Edit, k.Delete, k.Undo, k.Detail, k.Open, k.Copy, k.Help, k.Quit}
+}
+
+// DetailHelp is shown in the detail view's footer.
+func (k keyMap) DetailHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Open, k.Copy, k.Refresh}
+}
+
+// FullHelp is the ? overlay, one column per group.
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Top, k.Bottom, k.Expand, k.ExpandAll, k.CollapseAll},
+		{k.RefreshAll, k.Refresh, k.Detail, k.Analytics, k.Help, k.Quit},
+		{k.Add, k.Edit, k.Delete, k.Import, k.MoveUp, k.MoveDown, k.Pin, k.Undo, k.Redo},
+		{k.Open, k.OpenCompare, k.OpenRelease, k.Copy},
+	}
+}
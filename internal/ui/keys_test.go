@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+)
+
+// Every keyMap field must be reachable from config.KeyActions through
+// actions(), or it would be left unbound.
+func TestKeyMapMatchesActions(t *testing.T) {
+	var k keyMap
+	actions := k.actions()
+
+	fields := make(map[any]string)
+	v := reflect.ValueOf(&k).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		fields[v.Field(i).Addr().Interface()] = v.Type().Field(i).Name
+	}
+
+	seen := make(map[string]bool)
+	for _, a := range config.KeyActions {
+		b, ok := actions[a.Name]
+		if !ok {
+			t.Errorf("config.KeyActions has %q but keyMap.actions doesn't", a.Name)
+			continue
+		}
+		name, ok := fields[any(b)]
+		if !ok {
+			t.Errorf("action %q doesn't point at a keyMap field", a.Name)
+			continue
+		}
+		if seen[name] {
+			t.Errorf("keyMap.%s is bound to more than one action", name)
+		}
+		seen[name] = true
+	}
+	if len(actions) != len(config.KeyActions) {
+		t.Errorf("keyMap.actions has %d actions, config.KeyActions %d", len(actions), len(config.KeyActions))
+	}
+	for _, name := range fields {
+		if !seen[name] {
+			t.Errorf("keyMap.%s has no entry in config.KeyActions", name)
+		}
+	}
+}
+
+func TestDefaultKeyMapBindsEverything(t *testing.T) {
+	k := defaultKeyMap()
+	for name, b := range k.actions() {
+		if len(b.Keys()) == 0 {
+			t.Errorf("%s has no default keys", name)
+		}
+	}
+}
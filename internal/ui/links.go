@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"os/exec"
+	"runtime"
+
+	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectedStatus returns the last result for the repo under the cursor, or
+// just its owner/repo when it hasn't been checked yet.
+func (m Model) selectedStatus() (github.RepoStatus, bool) {
+	rows := m.rows()
+	if m.cursor >= len(rows) {
+		return github.RepoStatus{}, false
+	}
+	r := rows[m.cursor]
+	if res := m.results[repoKey(r.Owner, r.Repo)]; res != nil {
+		return *res, true
+	}
+	return github.RepoStatus{Owner: r.Owner, Repo: r.Repo}, true
+}
+
+// openURL opens url in the browser.
+func (m Model) openURL(url string) (tea.Model, tea.Cmd) {
+	if err := openBrowser(url); err != nil {
+		m.statusMsg = "Open failed: " + err.Error()
+	} else {
+		m.statusMsg = "Opened " + url
+	}
+	return m, nil
+}
+
+// copyText puts s (a link or SHA) on the system clipboard.
+func (m Model) copyText(s string) (tea.Model, tea.Cmd) {
+	if err := clipboard.WriteAll(s); err != nil {
+		m.statusMsg = "Copy failed: " + err.Error()
+	} else {
+		m.statusMsg = "Copied " + s
+	}
+	return m, nil
+}
+
+func openBrowser(url string) error {
+	var cmd string
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = "open"
+	case "windows":
+		cmd = "start"
+	default:
+		cmd = "xdg-open"
+	}
+	return exec.Command(cmd, url).Start()
+}
@@ -3,14 +3,23 @@ package ui
 import (
 	"context"
 	"fmt"
-	"os/exec"
-	"runtime"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/adhaniscuber/reprac/internal/checker"
 	"github.com/adhaniscuber/reprac/internal/config"
 	"github.com/adhaniscuber/reprac/internal/github"
+	"github.com/adhaniscuber/reprac/internal/history"
+	"github.com/adhaniscuber/reprac/internal/notify"
+	"github.com/adhaniscuber/reprac/internal/report"
+	"github.com/adhaniscuber/reprac/internal/sources"
+	"github.com/adhaniscuber/reprac/internal/state"
 	"github.com/adhaniscuber/reprac/internal/ui/components"
 	"github.com/adhaniscuber/reprac/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -27,40 +36,138 @@ type repoLoadingMsg struct {
 	key string
 }
 
+type notifyErrMsg struct {
+	err error
+}
+
+type trendMsg struct {
+	key    string
+	values []int
+}
+
+type importedMsg struct {
+	org   string
+	repos []config.RepoConfig
+	err   error
+}
+
+type sourcesMsg struct {
+	repos []config.RepoConfig
+	err   error
+}
+
+type analyticsMsg struct {
+	key    string
+	result report.Analytics
+}
+
+type detailMsg struct {
+	key  string
+	data components.DetailData
+}
+
 // ── Model ─────────────────────────────────────────────────────────────────────
 
+// trendDays is how many days of history the TREND sparkline covers.
+const trendDays = 14
+
+// analyticsReleases is how many recent releases the analytics panel samples.
+const analyticsReleases = 10
+
+// Options carries the file paths and collaborators the TUI needs besides the config.
+type Options struct {
+	ConfigPath  string
+	StatePath   string
+	HistoryPath string
+	Notifier    *notify.Notifier
+}
+
 type Model struct {
 	cfg       *config.Config
 	cfgPath   string
+	statePath string
 	gh        *github.Client
+	checker   *checker.Checker
+	notifier  *notify.Notifier
+	history   *history.Store
 	spinner   spinner.Model
 	results   map[string]*github.RepoStatus
+	cached    map[string]bool // results restored from the state file, not yet re-checked
+	trends    map[string][]int
 	loading   map[string]bool
 	expanded  map[string]bool
 	cursor    int
+	commitRow int // 0 on the repo row, n on the nth inline commit of the expanded cursor row
 	width     int
 	height    int
 	showModal bool
 	modal     components.AddRepoModal
+	editKey   string // repo being edited in modal; empty when adding
+	// table order: sortDir 0 keeps config order, otherwise 1/-1 sorts by column sortCol
+	sortCol, sortDir int
+	lastClick        click // for double-click detection
+	// confirmDelete is the repo awaiting y/n before it's deleted
+	confirmDelete string
+	undo, redo    []snapshot
+	// import-from-org overlay
+	showImport  bool
+	importModal components.ImportModal
+	// analytics overlay for analyticsKey; analytics is nil while loading
+	showAnalytics bool
+	analyticsKey  string
+	analytics     *report.Analytics
+	// full-screen detail view for detailKey; detail is nil until fetched
+	showDetail   bool
+	detailKey    string
+	detailTab    int
+	detailCursor int
+	detail       *components.DetailData
+	// key bindings, and whether the ? overlay listing them is open
+	keys     keyMap
+	showHelp bool
+
 	statusMsg string
 	noAuth    bool
 }
 
-func New(cfgPath string, cfg *config.Config, gh *github.Client) Model {
+func New(cfg *config.Config, gh *github.Client, opts Options) Model {
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
 	sp.Style = styles.Faint
 
-	return Model{
-		cfg:      cfg,
-		cfgPath:  cfgPath,
-		gh:       gh,
-		spinner:  sp,
-		results:  make(map[string]*github.RepoStatus),
-		loading:  make(map[string]bool),
-		expanded: make(map[string]bool),
-		noAuth:   !gh.HasAuth(),
+	m := Model{
+		cfg:       cfg,
+		cfgPath:   opts.ConfigPath,
+		statePath: opts.StatePath,
+		gh:        gh,
+		checker:   checker.New(gh),
+		notifier:  opts.Notifier,
+		history:   history.Open(opts.HistoryPath),
+		spinner:   sp,
+		results:   make(map[string]*github.RepoStatus),
+		cached:    make(map[string]bool),
+		trends:    make(map[string][]int),
+		loading:   make(map[string]bool),
+		expanded:  make(map[string]bool),
+		keys:      newKeyMap(cfg.Keys),
+		noAuth:    !gh.HasAuth(),
 	}
+
+	// Rules were validated when the config loaded.
+	_ = m.checker.SetRisks(cfg.RiskRules())
+
+	// Show last-known results (from the daemon or a previous session) while
+	// fresh checks run. A missing or unreadable state file just means no cache.
+	if st, err := state.Load(opts.StatePath); err == nil {
+		for _, r := range cfg.Repos {
+			key := repoKey(r.Owner, r.Repo)
+			if res, ok := st.Repos[key]; ok {
+				m.results[key] = &res
+				m.cached[key] = true
+			}
+		}
+	}
+	return m
 }
 
 func repoKey(owner, repo string) string {
@@ -72,7 +179,10 @@ func repoKey(owner, repo string) string {
 func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{m.spinner.Tick}
 	for _, r := range m.cfg.Repos {
-		cmds = append(cmds, m.checkRepo(r.Owner, r.Repo))
+		cmds = append(cmds, m.checkRepo(r), m.loadTrend(repoKey(r.Owner, r.Repo), nil))
+	}
+	if len(m.cfg.Sources) > 0 {
+		cmds = append(cmds, m.resolveSources())
 	}
 	return tea.Batch(cmds...)
 }
@@ -80,24 +190,100 @@ func (m Model) Init() tea.Cmd {
 // ── Update ────────────────────────────────────────────────────────────────────
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// If modal is open, route keys to it first
+	// If a modal is open, route keys to it first. Other messages (check
+	// results, ticks) go to the modal and then on to the dashboard so
+	// background work isn't lost while the user is typing.
+	var modalCmd tea.Cmd
 	if m.showModal {
 		switch msg := msg.(type) {
 		case components.ModalSubmitMsg:
+			if m.editKey != "" {
+				return m.handleEditRepo(msg.Result)
+			}
 			return m.handleAddRepo(msg.Result)
 		case components.ModalCancelMsg:
 			m.showModal = false
 			m.modal = components.AddRepoModal{}
+			m.editKey = ""
 			return m, nil
-		default:
+		case tea.KeyMsg:
 			var cmd tea.Cmd
 			m.modal, cmd = m.modal.Update(msg)
 			return m, cmd
+		default:
+			m.modal, modalCmd = m.modal.Update(msg)
+		}
+	}
+	if m.showImport {
+		switch msg := msg.(type) {
+		case components.ImportSubmitMsg:
+			m.showImport = false
+			m.importModal = components.ImportModal{}
+			m.statusMsg = fmt.Sprintf("Importing from %s...", msg.Filter.Org)
+			return m, m.importRepos(msg.Filter)
+		case components.ModalCancelMsg:
+			m.showImport = false
+			m.importModal = components.ImportModal{}
+			return m, nil
+		case tea.KeyMsg:
+			var cmd tea.Cmd
+			m.importModal, cmd = m.importModal.Update(msg)
+			return m, cmd
+		default:
+			m.importModal, modalCmd = m.importModal.Update(msg)
 		}
 	}
 
+	next, cmd := m.update(msg)
+	return next, tea.Batch(modalCmd, cmd)
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
+	case sourcesMsg:
+		if msg.err != nil {
+			m.statusMsg = msg.err.Error()
+		}
+		added := m.cfg.Merge(msg.repos)
+		cmds := make([]tea.Cmd, 0, len(added)*2)
+		for _, r := range added {
+			cmds = append(cmds, m.checkRepo(r), m.loadTrend(repoKey(r.Owner, r.Repo), nil))
+		}
+		return m, tea.Batch(cmds...)
+
+	case importedMsg:
+		if msg.err != nil {
+			m.statusMsg = "Import failed: " + msg.err.Error()
+			return m, nil
+		}
+		before := m.snapshot("import from " + msg.org)
+		added := m.cfg.Merge(msg.repos)
+		m.statusMsg = fmt.Sprintf("Imported %d repo(s), %d already tracked", len(added), len(msg.repos)-len(added))
+		if len(added) > 0 {
+			m.push(before)
+			m.save() // reports a failed write over the message above
+		}
+		cmds := make([]tea.Cmd, 0, len(added))
+		for _, r := range added {
+			cmds = append(cmds, m.checkRepo(r))
+		}
+		return m, tea.Batch(cmds...)
+
+	case detailMsg:
+		if msg.key == m.detailKey {
+			data := msg.data
+			m.detail = &data
+		}
+		return m, nil
+
+	case analyticsMsg:
+		if msg.key == m.analyticsKey {
+			res := msg.result
+			m.analytics = &res
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -115,99 +301,223 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case repoCheckedMsg:
 		delete(m.loading, msg.key)
 		result := msg.result
+		// Transitions against a cached result already happened while we
+		// weren't watching; the daemon is responsible for those.
+		var events []notify.Event
+		if !m.cached[msg.key] {
+			events = notify.Diff(m.results[msg.key], result, m.notifier.Threshold())
+		}
+		delete(m.cached, msg.key)
+		// A new result can move rows of a sorted table; keep the cursor on
+		// the same repo.
+		key, row := m.cursorKey(), m.commitRow
 		m.results[msg.key] = &result
+		if m.sortDir != 0 && key != "" {
+			m.selectKey(key)
+			m.commitRow = row
+		}
+		cmds := []tea.Cmd{m.sendNotifications(events), m.loadTrend(msg.key, &result)}
+		if len(m.loading) == 0 {
+			cmds = append(cmds, m.saveState())
+		}
+		return m, tea.Batch(cmds...)
+
+	case notifyErrMsg:
+		m.statusMsg = "Notify failed: " + msg.err.Error()
+		return m, nil
+
+	case trendMsg:
+		m.trends[msg.key] = msg.values
 		return m, nil
 
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	}
 
 	return m, nil
 }
 
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	repos := m.cfg.Repos
+	repos := m.rows()
 
-	switch msg.String() {
-	case "q", "ctrl+c":
+	if msg.String() == "ctrl+c" {
 		return m, tea.Quit
+	}
+
+	if m.showHelp {
+		m.showHelp = false
+		return m, nil
+	}
 
-	case "up", "k":
-		if m.cursor > 0 {
-			m.cursor--
+	if m.showAnalytics {
+		if msg.String() == "esc" || key.Matches(msg, m.keys.Analytics, m.keys.Quit) {
+			m.showAnalytics = false
+			m.analytics = nil
 		}
+		return m, nil
+	}
 
-	case "down", "j":
-		if m.cursor < len(repos)-1 {
-			m.cursor++
+	if m.showDetail {
+		return m.handleDetailKey(msg)
+	}
+
+	if m.confirmDelete != "" {
+		pending := m.confirmDelete
+		m.confirmDelete = ""
+		if msg.String() == "y" || msg.String() == "Y" {
+			return m.deleteRepo(pending)
 		}
+		m.statusMsg = "Delete cancelled"
+		return m, nil
+	}
 
-	case "g":
-		m.cursor = 0
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
 
-	case "G":
-		m.cursor = len(repos) - 1
+	case key.Matches(msg, m.keys.Up):
+		m = m.moveCursor(-1)
 
-	case "r":
+	case key.Matches(msg, m.keys.Down):
+		m = m.moveCursor(1)
+
+	case key.Matches(msg, m.keys.Top):
+		m.cursor, m.commitRow = 0, 0
+
+	case key.Matches(msg, m.keys.Bottom):
+		m.cursor, m.commitRow = max(len(repos)-1, 0), 0
+
+	case key.Matches(msg, m.keys.RefreshAll):
 		// Refresh all
 		cmds := []tea.Cmd{}
 		for _, r := range repos {
-			cmds = append(cmds, m.checkRepo(r.Owner, r.Repo))
+			cmds = append(cmds, m.checkRepo(r))
 		}
 		m.statusMsg = "Refreshing all..."
 		return m, tea.Batch(cmds...)
 
-	case "R":
+	case key.Matches(msg, m.keys.Refresh):
 		// Refresh selected row
 		if len(repos) > 0 && m.cursor < len(repos) {
 			r := repos[m.cursor]
 			m.statusMsg = fmt.Sprintf("Refreshing %s/%s...", r.Owner, r.Repo)
-			return m, m.checkRepo(r.Owner, r.Repo)
+			return m, m.checkRepo(r)
 		}
 
-	case "enter", " ":
+	case key.Matches(msg, m.keys.Expand):
+		if m.commitLine() > 0 {
+			return m.showCommit()
+		}
 		if len(repos) > 0 && m.cursor < len(repos) {
 			key := repoKey(repos[m.cursor].Owner, repos[m.cursor].Repo)
 			m.expanded[key] = !m.expanded[key]
+			m.commitRow = 0
 		}
 
-	case "E":
+	case key.Matches(msg, m.keys.ExpandAll):
 		for _, r := range repos {
 			m.expanded[repoKey(r.Owner, r.Repo)] = true
 		}
 
-	case "C":
+	case key.Matches(msg, m.keys.CollapseAll):
 		m.expanded = make(map[string]bool)
+		m.commitRow = 0
 
-	case "a":
+	case key.Matches(msg, m.keys.Add):
 		m.showModal = true
 		m.modal = components.NewAddRepoModal(m.width, m.height)
 		return m, nil
 
-	case "d":
+	case key.Matches(msg, m.keys.Edit):
+		if len(repos) > 0 && m.cursor < len(repos) {
+			r := repos[m.cursor]
+			if r.Origin != "" {
+				m.statusMsg = fmt.Sprintf("%s comes from %s — edit it there", repoKey(r.Owner, r.Repo), r.Origin)
+				return m, nil
+			}
+			m.showModal = true
+			m.editKey = repoKey(r.Owner, r.Repo)
+			m.modal = components.NewEditRepoModal(r, m.width, m.height)
+			return m, nil
+		}
+
+	case key.Matches(msg, m.keys.Delete):
 		if len(repos) > 0 && m.cursor < len(repos) {
 			r := repos[m.cursor]
 			key := repoKey(r.Owner, r.Repo)
-			m.cfg.Repos = append(repos[:m.cursor], repos[m.cursor+1:]...)
-			delete(m.results, key)
-			delete(m.loading, key)
-			if m.cursor >= len(m.cfg.Repos) && m.cursor > 0 {
-				m.cursor--
+			if r.Origin != "" {
+				m.statusMsg = fmt.Sprintf("%s comes from %s — change the rule in the config to drop it", key, r.Origin)
+				return m, nil
 			}
-			_ = config.Save(m.cfgPath, m.cfg)
-			m.statusMsg = fmt.Sprintf("Removed %s/%s", r.Owner, r.Repo)
+			m.confirmDelete = key
+			m.statusMsg = fmt.Sprintf("Delete %s from the config? (y/n)", key)
 		}
 
-	case "o":
+	case key.Matches(msg, m.keys.MoveUp):
+		return m.moveRepo(-1)
+
+	case key.Matches(msg, m.keys.MoveDown):
+		return m.moveRepo(1)
+
+	case key.Matches(msg, m.keys.Pin):
+		return m.togglePin()
+
+	case key.Matches(msg, m.keys.Undo):
+		return m.undoChange()
+
+	case key.Matches(msg, m.keys.Redo):
+		return m.redoChange()
+
+	case key.Matches(msg, m.keys.Import):
+		m.showImport = true
+		m.importModal = components.NewImportModal(m.width, m.height)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Analytics):
 		if len(repos) > 0 && m.cursor < len(repos) {
 			r := repos[m.cursor]
-			url := fmt.Sprintf("https://github.com/%s/%s", r.Owner, r.Repo)
-			_ = openBrowser(url)
+			m.showAnalytics = true
+			m.analyticsKey = repoKey(r.Owner, r.Repo)
+			m.analytics = nil
+			return m, m.loadAnalytics(r)
+		}
+
+	case key.Matches(msg, m.keys.Detail):
+		if key := m.cursorKey(); key != "" {
+			return m.openDetail(key, components.TabCommits, max(m.commitLine()-1, 0))
+		}
+
+	case key.Matches(msg, m.keys.Open):
+		if s, c, ok := m.selectedCommit(); ok {
+			return m.openURL(s.CommitURL(c.SHA))
+		}
+		if s, ok := m.selectedStatus(); ok {
+			return m.openURL(s.URL())
+		}
+
+	case key.Matches(msg, m.keys.OpenCompare):
+		if s, ok := m.selectedStatus(); ok {
+			return m.openURL(s.CompareURL())
+		}
+
+	case key.Matches(msg, m.keys.OpenRelease):
+		if s, ok := m.selectedStatus(); ok {
+			return m.openURL(s.ReleaseURL())
+		}
+
+	case key.Matches(msg, m.keys.Copy):
+		if _, c, ok := m.selectedCommit(); ok {
+			return m.copyText(c.SHA)
+		}
+		if s, ok := m.selectedStatus(); ok {
+			return m.copyText(s.CompareURL())
 		}
 
-	case "?":
-		// Toggle help via statusMsg
-		m.statusMsg = "enter/space=expand  E=expand all  C=collapse all  r=refresh all  R=refresh row  a=add  d=delete  o=browser  j/k=move  q=quit"
+	case key.Matches(msg, m.keys.Help):
+		m.showHelp = true
 	}
 
 	return m, nil
@@ -219,36 +529,199 @@ func (m Model) handleAddRepo(res components.AddRepoResult) (tea.Model, tea.Cmd)
 
 	// Check for duplicate
 	key := repoKey(res.Owner, res.Repo)
-	for _, r := range m.cfg.Repos {
-		if repoKey(r.Owner, r.Repo) == key {
-			m.statusMsg = fmt.Sprintf("Repo %s already tracked", key)
-			return m, nil
-		}
+	if m.cfg.Has(res.Owner, res.Repo) {
+		m.statusMsg = fmt.Sprintf("Repo %s already tracked", key)
+		return m, nil
 	}
 
-	m.cfg.Repos = append(m.cfg.Repos, config.RepoConfig{
-		Owner: res.Owner,
-		Repo:  res.Repo,
-		Notes: res.Notes,
-	})
-	_ = config.Save(m.cfgPath, m.cfg)
+	r := config.RepoConfig{
+		Owner:            res.Owner,
+		Repo:             res.Repo,
+		Notes:            res.Notes,
+		MaxUnreleasedAge: res.MaxUnreleasedAge,
+	}
+	m.checkpoint("add " + key)
+	m.cfg.Repos = append(m.cfg.Repos, r)
 	m.statusMsg = fmt.Sprintf("Added %s", key)
-	return m, m.checkRepo(res.Owner, res.Repo)
+	m.save()
+	return m, m.checkRepo(r)
+}
+
+// deleteRepo removes key from the config once the user has confirmed.
+func (m Model) deleteRepo(key string) (tea.Model, tea.Cmd) {
+	idx := slices.IndexFunc(m.cfg.Repos, func(r config.RepoConfig) bool {
+		return repoKey(r.Owner, r.Repo) == key
+	})
+	if idx < 0 {
+		return m, nil
+	}
+	m.checkpoint("delete " + key)
+	m.cfg.Repos = slices.Delete(slices.Clone(m.cfg.Repos), idx, idx+1)
+	delete(m.results, key)
+	delete(m.loading, key)
+	if m.cursor >= len(m.cfg.Repos) && m.cursor > 0 {
+		m.cursor--
+	}
+	m.commitRow = 0
+	m.statusMsg = fmt.Sprintf("Removed %s (u to undo)", key)
+	m.save()
+	return m, nil
+}
+
+// handleEditRepo applies the edit dialog to the entry it was opened for,
+// re-checking the repo if it now points somewhere else.
+func (m Model) handleEditRepo(res components.AddRepoResult) (tea.Model, tea.Cmd) {
+	oldKey := m.editKey
+	m.showModal = false
+	m.modal = components.AddRepoModal{}
+	m.editKey = ""
+
+	idx := -1
+	for i, r := range m.cfg.Repos {
+		if repoKey(r.Owner, r.Repo) == oldKey {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return m, nil
+	}
+
+	key := repoKey(res.Owner, res.Repo)
+	moved := key != oldKey
+	if moved && m.cfg.Has(res.Owner, res.Repo) {
+		m.statusMsg = fmt.Sprintf("Repo %s already tracked", key)
+		return m, nil
+	}
+
+	m.checkpoint("edit " + oldKey)
+	r := &m.cfg.Repos[idx]
+	r.Owner, r.Repo, r.Notes, r.MaxUnreleasedAge = res.Owner, res.Repo, res.Notes, res.MaxUnreleasedAge
+	if err := config.Save(m.cfgPath, m.cfg); err != nil {
+		m.statusMsg = "Save failed: " + err.Error()
+	} else {
+		m.statusMsg = fmt.Sprintf("Updated %s", key)
+	}
+	if !moved {
+		return m, nil
+	}
+
+	delete(m.results, oldKey)
+	delete(m.cached, oldKey)
+	delete(m.loading, oldKey)
+	delete(m.trends, oldKey)
+	if m.expanded[oldKey] {
+		delete(m.expanded, oldKey)
+		m.expanded[key] = true
+	}
+	return m, tea.Batch(m.checkRepo(*r), m.loadTrend(key, nil))
 }
 
 // ── Async check ───────────────────────────────────────────────────────────────
 
-func (m Model) checkRepo(owner, repo string) tea.Cmd {
-	key := repoKey(owner, repo)
+func (m Model) checkRepo(r config.RepoConfig) tea.Cmd {
+	key := repoKey(r.Owner, r.Repo)
 	m.loading[key] = true
 	return func() tea.Msg {
-		// First emit loading state
-		_ = key
-		result := m.gh.CheckRepo(context.Background(), owner, repo)
+		result := m.checker.Check(context.Background(), r)
 		return repoCheckedMsg{key: key, result: result}
 	}
 }
 
+// loadTrend records result (if any) into the history store and reloads the
+// repo's sparkline values.
+func (m Model) loadTrend(key string, result *github.RepoStatus) tea.Cmd {
+	store := m.history
+	return func() tea.Msg {
+		if result != nil {
+			_ = store.Add(*result)
+		}
+		now := time.Now()
+		records, err := store.Query(key, now.AddDate(0, 0, -trendDays))
+		if err != nil {
+			return nil
+		}
+		return trendMsg{key: key, values: history.Daily(records, trendDays, now)}
+	}
+}
+
+// resolveSources expands the config's sources in the background so startup
+// isn't blocked on listing orgs.
+func (m Model) resolveSources() tea.Cmd {
+	gh, cfg := m.gh, m.cfg
+	return func() tea.Msg {
+		repos, err := sources.Resolve(context.Background(), gh, cfg)
+		return sourcesMsg{repos: repos, err: err}
+	}
+}
+
+// importRepos lists repos matching f; merging happens on importedMsg.
+func (m Model) importRepos(f github.RepoFilter) tea.Cmd {
+	gh := m.gh
+	return func() tea.Msg {
+		found, err := gh.ListRepos(context.Background(), f)
+		if err != nil {
+			return importedMsg{org: f.Org, err: err}
+		}
+		repos := make([]config.RepoConfig, len(found))
+		for i, r := range found {
+			repos[i] = config.RepoConfig{Owner: r.Owner, Repo: r.Name}
+		}
+		return importedMsg{org: f.Org, repos: repos}
+	}
+}
+
+// loadAnalytics computes release cadence for r, reusing its latest check for drift.
+func (m Model) loadAnalytics(r config.RepoConfig) tea.Cmd {
+	key := repoKey(r.Owner, r.Repo)
+	var status github.RepoStatus
+	if res, ok := m.results[key]; ok {
+		status = *res
+	}
+	gh, chk := m.gh, m.checker
+	return func() tea.Msg {
+		ctx := context.Background()
+		if status.Owner == "" {
+			status = chk.Check(ctx, r)
+		}
+		return analyticsMsg{key: key, result: report.Analyze(ctx, gh, status, analyticsReleases)}
+	}
+}
+
+// saveState persists the current results so the next start opens instantly.
+func (m Model) saveState() tea.Cmd {
+	st := &state.State{Repos: make(map[string]github.RepoStatus, len(m.results))}
+	for _, res := range m.results {
+		st.Put(*res)
+	}
+	path := m.statePath
+	return func() tea.Msg {
+		// Merge with what's on disk so repos tracked elsewhere aren't dropped.
+		if disk, err := state.Load(path); err == nil {
+			for k, v := range disk.Repos {
+				if _, ok := st.Repos[k]; !ok {
+					st.Repos[k] = v
+				}
+			}
+		}
+		_ = state.Save(path, st)
+		return nil
+	}
+}
+
+func (m Model) sendNotifications(events []notify.Event) tea.Cmd {
+	if len(events) == 0 || !m.notifier.Enabled() {
+		return nil
+	}
+	n := m.notifier
+	return func() tea.Msg {
+		if err := n.Send(context.Background(), events); err != nil {
+			return notifyErrMsg{err: err}
+		}
+		return nil
+	}
+}
+
 // ── View ──────────────────────────────────────────────────────────────────────
 
 func (m Model) View() string {
@@ -260,62 +733,32 @@ func (m Model) View() string {
 		return m.modal.View()
 	}
 
-	const leftWidth = 52
-
-	// ── Left panel: ASCII art + tagline ───────────────────────────────────
-	asciiArt := styles.HeaderTitle.Render(
-		"    ________  ____  _________  _____\n" +
-			"   / ___/ _ \\/ __ \\/ ___/ __ `/ ___/\n" +
-			"  / /  /  __/ /_/ / /  / /_/ / /__  \n" +
-			" /_/   \\___/ ____/_/   \\__,_/\\___/  \n" +
-			"          /_/",
-	)
-	leftContent := "\n" + asciiArt + "\n\n" +
-		styles.HeaderSub.Render("  track unreleased changes") + "\n"
-	leftPanel := components.RenderTitledPanel("", leftContent, leftWidth, 9, styles.ColorPrimary)
+	if m.showImport {
+		return m.importModal.View()
+	}
 
-	// ── Right panel: repo overview ─────────────────────────────────────────
-	rightWidth := m.width - leftWidth
-	total := len(m.cfg.Repos)
-	pending, clean, noRelease := 0, 0, 0
-	loading := len(m.loading)
-	for _, r := range m.cfg.Repos {
-		key := repoKey(r.Owner, r.Repo)
-		if res, ok := m.results[key]; ok {
-			switch res.Status {
-			case github.StatusBehind:
-				pending++
-			case github.StatusClean:
-				clean++
-			case github.StatusNoRelease:
-				noRelease++
-			}
-		}
+	if m.showDetail {
+		return components.RenderDetail(m.results[m.detailKey], m.detail, m.detailTab, m.detailCursor, m.width, m.height, m.keys.DetailHelp())
 	}
-	rightContent := buildOverview(total, pending, clean, noRelease, loading, m.noAuth)
-	rightPanel := components.RenderTitledPanel("overview", rightContent, rightWidth, 9, styles.ColorSubtle)
 
-	topRow := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
-	topRowHeight := lipgloss.Height(topRow)
+	if m.showHelp {
+		return components.RenderHelp(m.keys, m.width, m.height)
+	}
 
-	// ── Table panel ───────────────────────────────────────────────────────
-	footerHeight := 1
-	statusHeight := 1
-	tablePanelHeight := m.height - topRowHeight - footerHeight - statusHeight
-	if tablePanelHeight < 4 {
-		tablePanelHeight = 4
+	if m.showAnalytics {
+		return components.RenderAnalytics(m.analyticsKey, m.analytics, m.width, m.height)
 	}
 
+	topRow := m.renderTopRow()
+
+	// ── Table panel ───────────────────────────────────────────────────────
 	tableInner := m.width - 2 // panel left+right border
-	headerStr := components.RenderHeader(tableInner)
-	headerHeight := lipgloss.Height(headerStr)
-	dataHeight := tablePanelHeight - 2 - headerHeight // -2 for panel top+bottom border
-	if dataHeight < 1 {
-		dataHeight = 1
-	}
+	headerStr := components.RenderHeader(tableInner, m.sortCol, m.sortDir)
+	_, dataHeight := m.tableLayout()
 
-	repos := m.cfg.Repos
-	start, end := scrollWindow(m.cursor, repos, m.expanded, m.results, dataHeight)
+	repos := m.rows()
+	commitLine := m.commitLine()
+	start, end := scrollWindow(m.cursor, commitLine, repos, m.expanded, m.results, dataHeight)
 
 	var rows []string
 	usedHeight := 0
@@ -326,7 +769,11 @@ func (m Model) View() string {
 		isLoading := m.loading[key]
 		isExpanded := m.expanded[key]
 
-		row := components.RenderRow(i, i == m.cursor, key, r.Owner, r.Repo, r.Notes, res, isLoading, isExpanded, tableInner)
+		selected, commit := i == m.cursor, 0
+		if selected {
+			commit = commitLine
+		}
+		row := components.RenderRow(i, selected, commit, key, r, res, m.trends[key], isLoading, isExpanded, tableInner)
 		rows = append(rows, row)
 		usedHeight += rowHeight(key, isExpanded, m.results)
 		if usedHeight >= dataHeight {
@@ -350,12 +797,94 @@ func (m Model) View() string {
 	}
 
 	// ── Footer ────────────────────────────────────────────────────────────
-	footer := components.RenderFooter(m.width, false)
+	footer := components.RenderFooter(m.width, false, m.keys.ShortHelp())
 
 	return strings.Join([]string{topRow, tablePanel, statusBar, footer}, "\n")
 }
 
-func buildOverview(total, pending, clean, noRelease, loading int, noAuth bool) string {
+// renderTopRow draws the logo and overview panels above the table.
+func (m Model) renderTopRow() string {
+	const leftWidth = 52
+
+	// ── Left panel: ASCII art + tagline ───────────────────────────────────
+	asciiArt := styles.HeaderTitle.Render(
+		"    ________  ____  _________  _____\n" +
+			"   / ___/ _ \\/ __ \\/ ___/ __ `/ ___/\n" +
+			"  / /  /  __/ /_/ / /  / /_/ / /__  \n" +
+			" /_/   \\___/ ____/_/   \\__,_/\\___/  \n" +
+			"          /_/",
+	)
+	leftContent := "\n" + asciiArt + "\n\n" +
+		styles.HeaderSub.Render("  track unreleased changes") + "\n" +
+		styles.Faint.Render("  "+displayPath(m.cfgPath, leftWidth-6))
+	leftPanel := components.RenderTitledPanel("", leftContent, leftWidth, 9, styles.ColorPrimary)
+
+	// ── Right panel: repo overview ─────────────────────────────────────────
+	rightWidth := m.width - leftWidth
+	total := len(m.cfg.Repos)
+	pending, broken, diverged, clean, noRelease := 0, 0, 0, 0, 0
+	loading := len(m.loading)
+	for _, r := range m.cfg.Repos {
+		key := repoKey(r.Owner, r.Repo)
+		if res, ok := m.results[key]; ok {
+			switch res.Status {
+			case github.StatusBehind:
+				pending++
+			case github.StatusBroken:
+				pending++
+				broken++
+			case github.StatusDiverged:
+				diverged++
+				if res.CI == github.CIFailing {
+					broken++
+				}
+			case github.StatusClean:
+				clean++
+			case github.StatusNoRelease:
+				noRelease++
+			}
+		}
+	}
+	rightContent := buildOverview(total, pending, broken, diverged, clean, noRelease, loading, m.noAuth)
+	rightPanel := components.RenderTitledPanel("overview", rightContent, rightWidth, 9, styles.ColorSubtle)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
+}
+
+// displayPath shortens the home directory to ~ and trims path from the left
+// to fit width, so the file name stays visible.
+func displayPath(path string, width int) string {
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = filepath.Join("~", rel)
+		}
+	}
+	runes := []rune(path)
+	if len(runes) > width && width > 1 {
+		path = "…" + string(runes[len(runes)-width+1:])
+	}
+	return path
+}
+
+// tableLayout returns the screen line where table rows start (below the
+// header) and how many lines they get.
+func (m Model) tableLayout() (top, height int) {
+	topRowHeight := lipgloss.Height(m.renderTopRow())
+	footerHeight := 1
+	statusHeight := 1
+	tablePanelHeight := m.height - topRowHeight - footerHeight - statusHeight
+	if tablePanelHeight < 4 {
+		tablePanelHeight = 4
+	}
+	headerHeight := lipgloss.Height(components.RenderHeader(m.width-2, m.sortCol, m.sortDir))
+	height = tablePanelHeight - 2 - headerHeight // -2 for panel top+bottom border
+	if height < 1 {
+		height = 1
+	}
+	return topRowHeight + 1 + headerHeight, height
+}
+
+func buildOverview(total, pending, broken, diverged, clean, noRelease, loading int, noAuth bool) string {
 	var lines []string
 	lines = append(lines, "")
 	if loading > 0 {
@@ -364,6 +893,12 @@ func buildOverview(total, pending, clean, noRelease, loading int, noAuth bool) s
 	if pending > 0 {
 		lines = append(lines, styles.CommitsAhead.Render(fmt.Sprintf("  ▲  %d  need deploy", pending)))
 	}
+	if broken > 0 {
+		lines = append(lines, styles.BadgeError.Render(fmt.Sprintf("  ✗  %d  with failing CI", broken)))
+	}
+	if diverged > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(styles.ColorPrimary).Render(fmt.Sprintf("  ⑂  %d  diverged from release", diverged)))
+	}
 	if clean > 0 {
 		lines = append(lines, styles.BadgeClean.Render(fmt.Sprintf("  ✓  %d  up to date", clean)))
 	}
@@ -384,62 +919,52 @@ func rowHeight(key string, expanded bool, results map[string]*github.RepoStatus)
 		return 1
 	}
 	res := results[key]
-	if res == nil || res.Status != github.StatusBehind || len(res.Commits) == 0 {
+	if res == nil || !res.NeedsDeploy() || len(res.Commits) == 0 {
 		return 1
 	}
-	h := 1 + len(res.Commits) // header + commit lines
-	if res.CommitsAhead > len(res.Commits) {
+	shown := min(len(res.Commits), github.InlineCommits)
+	h := 1 + shown // header + commit lines
+	if res.CommitsAhead > shown {
 		h++ // "+N more commits" line
 	}
 	return h
 }
 
-func scrollWindow(cursor int, repos []config.RepoConfig, expanded map[string]bool, results map[string]*github.RepoStatus, height int) (start, end int) {
+// scrollWindow returns the range of repos to render so that the cursor row
+// is visible down to its selected commit line (0 for the row itself).
+func scrollWindow(cursor, commitLine int, repos []config.RepoConfig, expanded map[string]bool, results map[string]*github.RepoStatus, height int) (start, end int) {
 	total := len(repos)
 	if total == 0 {
 		return 0, 0
 	}
+	heightOf := func(i int) int {
+		key := repoKey(repos[i].Owner, repos[i].Repo)
+		return rowHeight(key, expanded[key], results)
+	}
 
 	// Try to center around cursor
-	// First pass: find a start such that cursor is roughly in the middle
 	start = cursor - height/2
 	if start < 0 {
 		start = 0
 	}
 
+	// Shift start forward until the selected line fits
+	above := 0
+	for i := start; i < cursor; i++ {
+		above += heightOf(i)
+	}
+	for start < cursor && above+commitLine+1 > height {
+		above -= heightOf(start)
+		start++
+	}
+
 	// Forward pass from start: accumulate height until we fill tableHeight
 	used := 0
 	end = start
 	for end < total && used < height {
-		key := repoKey(repos[end].Owner, repos[end].Repo)
-		used += rowHeight(key, expanded[key], results)
+		used += heightOf(end)
 		end++
 	}
 
-	// If cursor is beyond end, shift start forward
-	if cursor >= end {
-		start = cursor
-		used = 0
-		end = start
-		for end < total && used < height {
-			key := repoKey(repos[end].Owner, repos[end].Repo)
-			used += rowHeight(key, expanded[key], results)
-			end++
-		}
-	}
-
 	return start, end
 }
-
-func openBrowser(url string) error {
-	var cmd string
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = "open"
-	case "windows":
-		cmd = "start"
-	default:
-		cmd = "xdg-open"
-	}
-	return exec.Command(cmd, url).Start()
-}
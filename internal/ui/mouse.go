@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// doubleClick is the longest gap between two clicks on the same line that
+// counts as a double-click.
+const doubleClick = 400 * time.Millisecond
+
+// click is a left click on a table line: a repo row (line 0) or one of its
+// inline commits.
+type click struct {
+	row, line int
+	at        time.Time
+}
+
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	// Overlays and the delete prompt own the screen; a click behind them
+	// mustn't move the cursor away from the repo the prompt names.
+	if m.showModal || m.showImport || m.showAnalytics || m.showHelp || m.confirmDelete != "" {
+		return m, nil
+	}
+	if m.showDetail {
+		items := components.DetailItems(m.results[m.detailKey], m.detail, m.detailTab)
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.detailCursor = max(m.detailCursor-1, 0)
+		case tea.MouseButtonWheelDown:
+			m.detailCursor = max(min(m.detailCursor+1, items-1), 0)
+		}
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.moveCursor(-1), nil
+	case tea.MouseButtonWheelDown:
+		return m.moveCursor(1), nil
+	case tea.MouseButtonLeft:
+		if msg.Action == tea.MouseActionPress {
+			return m.handleClick(msg.X, msg.Y)
+		}
+	}
+	return m, nil
+}
+
+// handleClick sorts on a header click, selects the repo or commit line under
+// a row click, and expands (or opens the commit) on a double-click.
+func (m Model) handleClick(x, y int) (tea.Model, tea.Cmd) {
+	top, height := m.tableLayout()
+	tableInner := m.width - 2 // panel left+right border
+	header := lipgloss.Height(components.RenderHeader(tableInner, m.sortCol, m.sortDir))
+	if y < top && y >= top-header {
+		return m.sortBy(components.ColumnAt(x-1, tableInner)), nil
+	}
+	if y < top || y >= top+height {
+		return m, nil
+	}
+
+	repos := m.rows()
+	start, end := scrollWindow(m.cursor, m.commitLine(), repos, m.expanded, m.results, height)
+	offset := y - top
+	for i := start; i < end && i < len(repos); i++ {
+		key := repoKey(repos[i].Owner, repos[i].Repo)
+		h := rowHeight(key, m.expanded[key], m.results)
+		if offset >= h {
+			offset -= h
+			continue
+		}
+		line := offset
+		if line > m.inlineCommits(key) {
+			line = 0 // the "+N more commits" line selects the repo
+		}
+		m.cursor, m.commitRow = i, line
+
+		now := time.Now()
+		double := m.lastClick.row == i && m.lastClick.line == line && now.Sub(m.lastClick.at) < doubleClick
+		m.lastClick = click{row: i, line: line, at: now}
+		if !double {
+			return m, nil
+		}
+		m.lastClick = click{}
+		if line > 0 {
+			return m.showCommit()
+		}
+		m.expanded[key] = !m.expanded[key]
+		return m, nil
+	}
+	return m, nil
+}
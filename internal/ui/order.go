@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// moveRepo swaps the selected repo with the nearest repo listed in the file
+// itself in direction dir (-1 up, +1 down) and saves the new order. Included
+// and source repos are never saved, so they neither move nor count as
+// neighbours. Repos don't cross the pinned boundary.
+func (m Model) moveRepo(dir int) (tea.Model, tea.Cmd) {
+	if m.sortDir != 0 {
+		m.statusMsg = "The table is sorted — click the sorted header until it clears to reorder repos"
+		return m, nil
+	}
+	repos := m.cfg.Repos
+	i := m.cursor
+	if i < 0 || i >= len(repos) {
+		return m, nil
+	}
+	key := repoKey(repos[i].Owner, repos[i].Repo)
+	if repos[i].Origin != "" {
+		m.statusMsg = fmt.Sprintf("%s comes from %s — add it under repos: to move it", key, repos[i].Origin)
+		return m, nil
+	}
+	j := i + dir
+	for j >= 0 && j < len(repos) && repos[j].Origin != "" {
+		j += dir
+	}
+	if j < 0 || j >= len(repos) {
+		return m, nil
+	}
+	if repos[i].Pinned != repos[j].Pinned {
+		m.statusMsg = "Pinned repos stay above the rest — unpin with p to move it"
+		return m, nil
+	}
+
+	m.checkpoint("move " + key)
+	m.cfg.Repos = slices.Clone(repos)
+	m.cfg.Repos[i], m.cfg.Repos[j] = m.cfg.Repos[j], m.cfg.Repos[i]
+	m.cursor = j
+	m.save()
+	return m, nil
+}
+
+// togglePin pins or unpins the selected repo, moving it to the end of the
+// pinned group or the start of the unpinned one, and saves.
+func (m Model) togglePin() (tea.Model, tea.Cmd) {
+	key := m.cursorKey()
+	idx := slices.IndexFunc(m.cfg.Repos, func(r config.RepoConfig) bool { return repoKey(r.Owner, r.Repo) == key })
+	if idx < 0 {
+		return m, nil
+	}
+	r := m.cfg.Repos[idx]
+	if r.Origin != "" {
+		m.statusMsg = fmt.Sprintf("%s comes from %s — add it under repos: to pin it", key, r.Origin)
+		return m, nil
+	}
+
+	verb := "pin"
+	if r.Pinned {
+		verb = "unpin"
+	}
+	m.checkpoint(verb + " " + key)
+	r.Pinned = !r.Pinned
+	repos := slices.Delete(slices.Clone(m.cfg.Repos), idx, idx+1)
+	at := 0
+	for at < len(repos) && repos[at].Pinned {
+		at++
+	}
+	m.cfg.Repos = slices.Insert(repos, at, r)
+	m.selectKey(key)
+	m.save()
+	if r.Pinned {
+		m.statusMsg = "Pinned " + key
+	} else {
+		m.statusMsg = "Unpinned " + key
+	}
+	return m, nil
+}
+
+// save writes the config, reporting a failure in the status bar.
+func (m *Model) save() {
+	if err := config.Save(m.cfgPath, m.cfg); err != nil {
+		m.statusMsg = "Save failed: " + err.Error()
+	}
+}
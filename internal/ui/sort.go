@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	"github.com/adhaniscuber/reprac/internal/ui/components"
+)
+
+// rows returns the repos in display order: config order, or sorted by the
+// clicked column with pinned repos kept on top. m.cursor indexes this list.
+func (m Model) rows() []config.RepoConfig {
+	if m.sortDir == 0 {
+		return m.cfg.Repos
+	}
+	type keyed struct {
+		r    config.RepoConfig
+		num  float64
+		text string
+	}
+	now := time.Now()
+	ks := make([]keyed, len(m.cfg.Repos))
+	for i, r := range m.cfg.Repos {
+		num, text := components.SortKey(m.sortCol, r, m.results[repoKey(r.Owner, r.Repo)], now)
+		ks[i] = keyed{r, num, text}
+	}
+	slices.SortStableFunc(ks, func(a, b keyed) int {
+		if a.r.Pinned != b.r.Pinned {
+			if a.r.Pinned {
+				return -1
+			}
+			return 1
+		}
+		c := cmp.Compare(a.num, b.num)
+		if c == 0 {
+			c = strings.Compare(a.text, b.text)
+		}
+		return c * m.sortDir
+	})
+	rows := make([]config.RepoConfig, len(ks))
+	for i, k := range ks {
+		rows[i] = k.r
+	}
+	return rows
+}
+
+// sortBy cycles column col through its first sort direction, the reverse,
+// and back to config order, keeping the selected repo under the cursor.
+func (m Model) sortBy(col int) Model {
+	if col < 0 || components.Columns[col].Sort == 0 {
+		return m
+	}
+	key := m.cursorKey()
+	switch {
+	case m.sortDir == 0 || m.sortCol != col:
+		m.sortCol, m.sortDir = col, components.Columns[col].Sort
+	case m.sortDir == components.Columns[col].Sort:
+		m.sortDir = -m.sortDir
+	default:
+		m.sortDir = 0
+	}
+	m.selectKey(key)
+	if m.sortDir == 0 {
+		m.statusMsg = "Config order"
+	} else {
+		m.statusMsg = "Sorted by " + strings.ToLower(components.Columns[col].Title)
+	}
+	return m
+}
+
+// selectKey moves the cursor to the repo with key, if it's listed.
+func (m *Model) selectKey(key string) {
+	if i := slices.IndexFunc(m.rows(), func(r config.RepoConfig) bool { return repoKey(r.Owner, r.Repo) == key }); i >= 0 {
+		m.cursor = i
+		m.commitRow = 0
+	}
+}
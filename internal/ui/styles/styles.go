@@ -8,6 +8,7 @@ var (
 	ColorSecondary = lipgloss.Color("#89B4FA") // blue
 	ColorGreen     = lipgloss.Color("#A6E3A1") // green
 	ColorYellow    = lipgloss.Color("#F9E2AF") // yellow
+	ColorPeach     = lipgloss.Color("#FAB387") // peach
 	ColorRed       = lipgloss.Color("#F38BA8") // red
 	ColorCyan      = lipgloss.Color("#89DCEB") // sky
 	ColorGray      = lipgloss.Color("#6C7086") // overlay0
@@ -95,6 +96,29 @@ var (
 			Background(ColorYellow).
 			Padding(0, 1)
 
+	BadgeDeployWarning = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(ColorBg).
+				Background(ColorPeach).
+				Padding(0, 1)
+
+	BadgeDeployCritical = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(ColorBg).
+				Background(ColorRed).
+				Padding(0, 1)
+
+	BadgeBroken = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(ColorRed).
+			Padding(0, 1)
+
+	BadgeDiverged = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(ColorBg).
+			Background(ColorPrimary).
+			Padding(0, 1)
+
 	BadgeClean = lipgloss.NewStyle().
 			Foreground(ColorGreen)
 
@@ -135,6 +159,20 @@ var (
 
 	Timestamp = lipgloss.NewStyle().
 			Foreground(ColorMuted)
+
+	AgeWarning = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(ColorPeach)
+
+	Sparkline = lipgloss.NewStyle().
+			Foreground(ColorYellow)
+
+	Risk = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPeach)
+
+	Pinned = lipgloss.NewStyle().
+		Foreground(ColorPeach)
 )
 
 // ── Modal / Overlay ───────────────────────────────────────────────────────────
@@ -165,8 +203,32 @@ var (
 			Padding(0, 1)
 )
 
+// ── Tabs ──────────────────────────────────────────────────────────────────────
+
+var (
+	TabActive = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(ColorBg).
+			Background(ColorPrimary).
+			Padding(0, 2)
+
+	TabInactive = lipgloss.NewStyle().
+			Foreground(ColorGray).
+			Background(ColorSurface).
+			Padding(0, 2)
+)
+
 // ── Key Hints ─────────────────────────────────────────────────────────────────
 
+var (
+	HelpKey = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorSecondary)
+
+	HelpDesc = lipgloss.NewStyle().
+			Foreground(ColorText)
+)
+
 func KeyHint(key, desc string) string {
 	k := lipgloss.NewStyle().
 		Foreground(ColorBg).
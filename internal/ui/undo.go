@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/adhaniscuber/reprac/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxUndo caps how many changes u can step back through.
+const maxUndo = 50
+
+// snapshot is the repo list (and cursor) as it was before a change. Only the
+// file's own repos are kept; those from include: and sources: aren't edited
+// in the TUI and are merged back in on restore.
+type snapshot struct {
+	repos  []config.RepoConfig
+	cursor int
+	label  string // what the change did, e.g. "delete your-org/app"
+}
+
+func (m Model) snapshot(label string) snapshot {
+	var own []config.RepoConfig
+	for _, r := range m.cfg.Repos {
+		if r.Origin == "" {
+			own = append(own, r)
+		}
+	}
+	return snapshot{repos: own, cursor: m.cursor, label: label}
+}
+
+// checkpoint records the current repo list before a change labelled label.
+func (m *Model) checkpoint(label string) {
+	m.push(m.snapshot(label))
+}
+
+// push records s as the state before the latest change. A new change clears
+// the redo stack.
+func (m *Model) push(s snapshot) {
+	m.undo = append(m.undo, s)
+	if len(m.undo) > maxUndo {
+		m.undo = m.undo[len(m.undo)-maxUndo:]
+	}
+	m.redo = nil
+}
+
+// undoChange reverts the last change and saves the config.
+func (m Model) undoChange() (tea.Model, tea.Cmd) {
+	if len(m.undo) == 0 {
+		m.statusMsg = "Nothing to undo"
+		return m, nil
+	}
+	s := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+	m.redo = append(m.redo, m.snapshot(s.label))
+	m.statusMsg = "Undid " + s.label
+	return m, m.restore(s)
+}
+
+// redoChange re-applies the last undone change.
+func (m Model) redoChange() (tea.Model, tea.Cmd) {
+	if len(m.redo) == 0 {
+		m.statusMsg = "Nothing to redo"
+		return m, nil
+	}
+	s := m.redo[len(m.redo)-1]
+	m.redo = m.redo[:len(m.redo)-1]
+	m.undo = append(m.undo, m.snapshot(s.label))
+	m.statusMsg = "Redid " + s.label
+	return m, m.restore(s)
+}
+
+// restore replaces the file's own repos with s, keeping the current included
+// and source repos, saves it, and checks any repo that came back without a
+// result. A failed save replaces the status message.
+func (m *Model) restore(s snapshot) tea.Cmd {
+	var merged []config.RepoConfig
+	for _, r := range m.cfg.Repos {
+		if r.Origin != "" {
+			merged = append(merged, r)
+		}
+	}
+	key := m.cursorKey()
+	m.cfg.Repos = slices.Clone(s.repos)
+	m.cfg.Merge(merged)
+	m.cfg.SortPinned()
+	m.cursor = min(s.cursor, max(len(m.cfg.Repos)-1, 0))
+	m.commitRow = 0
+	if m.sortDir != 0 {
+		// Sorted rows don't follow config order; stay on the same repo.
+		m.selectKey(key)
+	}
+	if err := config.Save(m.cfgPath, m.cfg); err != nil {
+		m.statusMsg = fmt.Sprintf("Save failed: %v", err)
+	}
+
+	var cmds []tea.Cmd
+	for _, r := range m.cfg.Repos {
+		key := repoKey(r.Owner, r.Repo)
+		if m.results[key] == nil && !m.loading[key] {
+			cmds = append(cmds, m.checkRepo(r), m.loadTrend(key, nil))
+		}
+	}
+	return tea.Batch(cmds...)
+}